@@ -0,0 +1,124 @@
+package shredclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+// defaultALTCacheTTL 是从 RPC 拉取的地址查找表缓存的默认有效期。
+const defaultALTCacheTTL = 5 * time.Minute
+
+// altCacheEntry 保存一张已解析的地址查找表及其缓存时间。地址查找表账户本身只有
+// 一份地址列表，WritableIndexes/ReadonlyIndexes 都是对这同一份列表的索引。
+type altCacheEntry struct {
+	addresses []solana.PublicKey
+	cachedAt  time.Time
+}
+
+// ALTResolver 负责把 v0 交易 Message.AddressTableLookups 中的只读/可写索引，
+// 解析为完整的账户地址，以便 Filter 也能看到通过地址查找表引用的程序与账户。
+type ALTResolver struct {
+	rpcClient *rpc.Client
+	ttl       time.Duration
+
+	mu    sync.RWMutex
+	cache map[solana.PublicKey]altCacheEntry
+}
+
+// NewALTResolver 创建一个使用给定 RPC 端点拉取查找表的 ALTResolver。
+func NewALTResolver(rpcEndpoint string) *ALTResolver {
+	return &ALTResolver{
+		rpcClient: rpc.New(rpcEndpoint),
+		ttl:       defaultALTCacheTTL,
+		cache:     make(map[solana.PublicKey]altCacheEntry),
+	}
+}
+
+// WithCacheTTL 设置查找表的缓存有效期。
+func (r *ALTResolver) WithCacheTTL(ttl time.Duration) *ALTResolver {
+	r.ttl = ttl
+	return r
+}
+
+// PreloadTable 允许调用方为离线/无 RPC 场景预先灌入一张查找表的完整地址列表，
+// 跳过网络请求。
+func (r *ALTResolver) PreloadTable(table solana.PublicKey, addresses []solana.PublicKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache[table] = altCacheEntry{
+		addresses: addresses,
+		// 零值 cachedAt 意味着永不过期，用于静态预置的离线缓存。
+	}
+}
+
+// ResolveAccounts 解析 message 的所有 AddressTableLookups，返回解析得到的可写
+// 与只读账户地址。v0 之前的交易没有查找表，返回的切片为空。
+func (r *ALTResolver) ResolveAccounts(ctx context.Context, message *solana.Message) ([]solana.PublicKey, []solana.PublicKey, error) {
+	var writable, readonly []solana.PublicKey
+
+	for _, lookup := range message.AddressTableLookups {
+		table, err := r.getTable(ctx, lookup.AccountKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolve lookup table %s: %w", lookup.AccountKey, err)
+		}
+
+		for _, idx := range lookup.WritableIndexes {
+			if int(idx) >= len(table.addresses) {
+				return nil, nil, fmt.Errorf("writable index %d out of range for table %s", idx, lookup.AccountKey)
+			}
+			writable = append(writable, table.addresses[idx])
+		}
+		for _, idx := range lookup.ReadonlyIndexes {
+			if int(idx) >= len(table.addresses) {
+				return nil, nil, fmt.Errorf("readonly index %d out of range for table %s", idx, lookup.AccountKey)
+			}
+			readonly = append(readonly, table.addresses[idx])
+		}
+	}
+
+	return writable, readonly, nil
+}
+
+func (r *ALTResolver) getTable(ctx context.Context, table solana.PublicKey) (altCacheEntry, error) {
+	r.mu.RLock()
+	entry, ok := r.cache[table]
+	r.mu.RUnlock()
+	if ok && (entry.cachedAt.IsZero() || time.Since(entry.cachedAt) < r.ttl) {
+		return entry, nil
+	}
+
+	if r.rpcClient == nil {
+		return altCacheEntry{}, fmt.Errorf("no RPC endpoint configured and table %s is not preloaded", table)
+	}
+
+	info, err := r.rpcClient.GetAccountInfo(ctx, table)
+	if err != nil {
+		return altCacheEntry{}, fmt.Errorf("get account info: %w", err)
+	}
+	if info == nil || info.Value == nil {
+		return altCacheEntry{}, fmt.Errorf("lookup table %s not found", table)
+	}
+
+	var altAccount solana.AddressLookupTableState
+	if err := bin.NewBinDecoder(info.Value.Data.GetBinary()).Decode(&altAccount); err != nil {
+		return altCacheEntry{}, fmt.Errorf("decode address lookup table: %w", err)
+	}
+
+	entry = altCacheEntry{
+		addresses: altAccount.Addresses,
+		cachedAt:  time.Now(),
+	}
+
+	r.mu.Lock()
+	r.cache[table] = entry
+	r.mu.Unlock()
+
+	return entry, nil
+}
@@ -0,0 +1,84 @@
+package shredclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+func TestALTResolverResolveAccountsNoLookups(t *testing.T) {
+	r := NewALTResolver("")
+	message := &solana.Message{}
+
+	writable, readonly, err := r.ResolveAccounts(context.Background(), message)
+	if err != nil {
+		t.Fatalf("ResolveAccounts failed: %v", err)
+	}
+	if len(writable) != 0 || len(readonly) != 0 {
+		t.Fatalf("expected no resolved accounts for a legacy message, got writable=%d readonly=%d", len(writable), len(readonly))
+	}
+}
+
+func TestALTResolverResolveAccountsPreloaded(t *testing.T) {
+	r := NewALTResolver("")
+
+	table := solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	addresses := []solana.PublicKey{
+		solana.MustPublicKeyFromBase58("JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4"),
+		solana.MustPublicKeyFromBase58("LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo"),
+		solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P"),
+	}
+	r.PreloadTable(table, addresses)
+
+	message := &solana.Message{
+		AddressTableLookups: []solana.MessageAddressTableLookup{
+			{
+				AccountKey:      table,
+				WritableIndexes: []uint8{0, 2},
+				ReadonlyIndexes: []uint8{1},
+			},
+		},
+	}
+
+	writable, readonly, err := r.ResolveAccounts(context.Background(), message)
+	if err != nil {
+		t.Fatalf("ResolveAccounts failed: %v", err)
+	}
+	if len(writable) != 2 || !writable[0].Equals(addresses[0]) || !writable[1].Equals(addresses[2]) {
+		t.Fatalf("unexpected writable accounts: %v", writable)
+	}
+	if len(readonly) != 1 || !readonly[0].Equals(addresses[1]) {
+		t.Fatalf("unexpected readonly accounts: %v", readonly)
+	}
+}
+
+func TestALTResolverResolveAccountsIndexOutOfRange(t *testing.T) {
+	r := NewALTResolver("")
+
+	table := solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	r.PreloadTable(table, []solana.PublicKey{solana.MustPublicKeyFromBase58("JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4")})
+
+	message := &solana.Message{
+		AddressTableLookups: []solana.MessageAddressTableLookup{
+			{AccountKey: table, WritableIndexes: []uint8{5}},
+		},
+	}
+
+	if _, _, err := r.ResolveAccounts(context.Background(), message); err == nil {
+		t.Fatal("expected out-of-range writable index to return an error")
+	}
+}
+
+func TestALTResolverNoRPCAndNotPreloaded(t *testing.T) {
+	r := &ALTResolver{ttl: defaultALTCacheTTL, cache: make(map[solana.PublicKey]altCacheEntry)}
+
+	table := solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	message := &solana.Message{
+		AddressTableLookups: []solana.MessageAddressTableLookup{{AccountKey: table}},
+	}
+
+	if _, _, err := r.ResolveAccounts(context.Background(), message); err == nil {
+		t.Fatal("expected an error when the table is neither preloaded nor reachable via RPC")
+	}
+}
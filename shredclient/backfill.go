@@ -0,0 +1,207 @@
+package shredclient
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+const (
+	defaultBackfillQueueSize   = 1024
+	defaultBackfillRateLimit   = 100 * time.Millisecond
+	defaultBackfillMaxRetries  = 5
+	defaultBackfillBaseBackoff = 500 * time.Millisecond
+)
+
+// BackfillStats 是 BackfillWorker 的统计信息快照。
+type BackfillStats struct {
+	Backfilled int64
+	Errors     int64
+	Dropped    int64
+}
+
+// BackfillWorker 在检测到 slot 跳跃时，通过 Solana JSON-RPC 的 getBlock 把缺
+// 失的区块补回来，以 Source: SourceBackfill 的 TransactionEvent 喂给和实时流
+// 完全相同的 handler。
+type BackfillWorker struct {
+	rpcClient   *rpc.Client
+	queue       chan uint64
+	rateLimiter *time.Ticker
+	maxRetries  int
+	baseBackoff time.Duration
+	logger      *slog.Logger
+
+	backfilled int64
+	errors     int64
+	dropped    int64
+}
+
+// BackfillOption 定制 NewBackfillWorker 创建出的 BackfillWorker。
+type BackfillOption func(*BackfillWorker)
+
+// WithBackfillQueueSize 设置待回填 slot 的有界队列大小，默认 1024。队列满时
+// 新的 slot 会被丢弃而不是阻塞实时流。
+func WithBackfillQueueSize(n int) BackfillOption {
+	return func(w *BackfillWorker) { w.queue = make(chan uint64, n) }
+}
+
+// WithBackfillRateLimit 设置两次 getBlock 调用之间的最小间隔，默认 100ms。
+func WithBackfillRateLimit(interval time.Duration) BackfillOption {
+	return func(w *BackfillWorker) { w.rateLimiter = time.NewTicker(interval) }
+}
+
+// WithBackfillMaxRetries 设置单个 slot 在放弃前的最大重试次数，默认 5。
+func WithBackfillMaxRetries(n int) BackfillOption {
+	return func(w *BackfillWorker) { w.maxRetries = n }
+}
+
+// WithBackfillLogger 设置结构化日志输出，默认使用 slog.Default()。
+func WithBackfillLogger(logger *slog.Logger) BackfillOption {
+	return func(w *BackfillWorker) { w.logger = logger }
+}
+
+// NewBackfillWorker 创建一个使用 rpcEndpoint 回填缺失 slot 的 BackfillWorker。
+func NewBackfillWorker(rpcEndpoint string, opts ...BackfillOption) *BackfillWorker {
+	w := &BackfillWorker{
+		rpcClient:   rpc.New(rpcEndpoint),
+		queue:       make(chan uint64, defaultBackfillQueueSize),
+		rateLimiter: time.NewTicker(defaultBackfillRateLimit),
+		maxRetries:  defaultBackfillMaxRetries,
+		baseBackoff: defaultBackfillBaseBackoff,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if w.logger == nil {
+		w.logger = slog.Default()
+	}
+
+	return w
+}
+
+// Enqueue 把一个待回填的 slot 放入队列，队列已满时返回 false 且不阻塞调用方
+// （调用方通常是实时流的接收协程，不能被回填拖慢）。
+func (w *BackfillWorker) Enqueue(slot uint64) bool {
+	select {
+	case w.queue <- slot:
+		return true
+	default:
+		atomic.AddInt64(&w.dropped, 1)
+		return false
+	}
+}
+
+// Stats 返回当前的回填统计信息快照。
+func (w *BackfillWorker) Stats() BackfillStats {
+	return BackfillStats{
+		Backfilled: atomic.LoadInt64(&w.backfilled),
+		Errors:     atomic.LoadInt64(&w.errors),
+		Dropped:    atomic.LoadInt64(&w.dropped),
+	}
+}
+
+// Run 持续消费队列里的 slot 并回填，直到 ctx 取消。由 Client.Subscribe 在检测
+// 到 opts.Backfill 非空时自动启动，调用方一般不需要直接调用。
+func (w *BackfillWorker) Run(ctx context.Context, opts SubscribeOptions, events chan<- *TransactionEvent) {
+	defer w.rateLimiter.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case slot := <-w.queue:
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.rateLimiter.C:
+			}
+
+			if err := w.backfillSlot(ctx, slot, opts, events); err != nil {
+				atomic.AddInt64(&w.errors, 1)
+				w.logger.Warn("回填 slot 失败", slog.Uint64("slot", slot), slog.Any("err", err))
+				continue
+			}
+			atomic.AddInt64(&w.backfilled, 1)
+		}
+	}
+}
+
+func (w *BackfillWorker) backfillSlot(ctx context.Context, slot uint64, opts SubscribeOptions, events chan<- *TransactionEvent) error {
+	maxVersion := uint64(0)
+	var lastErr error
+
+	for attempt := 0; attempt <= w.maxRetries; attempt++ {
+		block, err := w.rpcClient.GetBlockWithOpts(ctx, slot, &rpc.GetBlockOpts{
+			MaxSupportedTransactionVersion: &maxVersion,
+		})
+		if err == nil {
+			w.emitBlock(slot, block, opts, events)
+			return nil
+		}
+
+		lastErr = err
+		if isSkippedSlotError(err) {
+			// leader 没有产出区块，重试没有意义，直接放弃这个 slot。
+			return fmt.Errorf("get block %d: slot skipped: %w", slot, err)
+		}
+		if !isRetryableBlockError(err) {
+			return fmt.Errorf("get block %d: %w", slot, err)
+		}
+
+		backoff := w.baseBackoff * time.Duration(1<<uint(attempt))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+
+	return fmt.Errorf("get block %d: giving up after %d retries: %w", slot, w.maxRetries, lastErr)
+}
+
+func (w *BackfillWorker) emitBlock(slot uint64, block *rpc.GetBlockResult, opts SubscribeOptions, events chan<- *TransactionEvent) {
+	for _, txWithMeta := range block.Transactions {
+		tx, err := txWithMeta.GetTransaction()
+		if err != nil {
+			continue
+		}
+
+		var matched []string
+		if len(opts.Filters) > 0 {
+			matched = matchFilters(opts.Filters, tx)
+			if len(matched) == 0 {
+				continue
+			}
+		}
+
+		events <- &TransactionEvent{
+			Slot:         slot,
+			Transaction:  tx,
+			MatchedBy:    matched,
+			Instructions: decodeInstructions(tx, opts.InstructionDecoders),
+			Source:       SourceBackfill,
+		}
+	}
+}
+
+// isRetryableBlockError 判断 getBlock 的错误是否值得重试。只有 slot 被跳过
+// 这种永久性错误才不值得重试；其余情况——区块暂时不可用、超时、连接中断、
+// RPC 限流等——都是 WithBackfillRateLimit/WithBackfillMaxRetries 本来就要
+// 覆盖的瞬时故障，应当重试而不是放弃整个 slot。
+func isRetryableBlockError(err error) bool {
+	return !isSkippedSlotError(err)
+}
+
+// isSkippedSlotError 判断错误是否表示该 slot 被跳过（leader 没有产出区块），
+// 这种情况重试没有意义，调用方应当放弃该 slot。
+func isSkippedSlotError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "skipped") || strings.Contains(msg, "missing in long-term storage")
+}
@@ -0,0 +1,64 @@
+package shredclient
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsSkippedSlotError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"skipped", errors.New("Slot 123 was skipped"), true},
+		{"long-term storage", errors.New("Block not available for slot 123: missing in long-term storage"), true},
+		{"timeout", errors.New("context deadline exceeded"), false},
+		{"rate limited", errors.New("429 Too Many Requests"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isSkippedSlotError(tc.err); got != tc.want {
+				t.Errorf("isSkippedSlotError(%q) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryableBlockError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"skipped is not retryable", errors.New("slot was skipped"), false},
+		{"timeout is retryable", errors.New("context deadline exceeded"), true},
+		{"connection reset is retryable", errors.New("connection reset by peer"), true},
+		{"rate limit is retryable", errors.New("429 Too Many Requests"), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isRetryableBlockError(tc.err); got != tc.want {
+				t.Errorf("isRetryableBlockError(%q) = %v, want %v", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBackfillWorkerEnqueueDropsWhenQueueFull(t *testing.T) {
+	w := NewBackfillWorker("", WithBackfillQueueSize(1))
+
+	if !w.Enqueue(1) {
+		t.Fatal("expected first Enqueue to succeed")
+	}
+	if w.Enqueue(2) {
+		t.Fatal("expected Enqueue to fail once the queue is full")
+	}
+
+	stats := w.Stats()
+	if stats.Dropped != 1 {
+		t.Fatalf("expected 1 dropped slot, got %d", stats.Dropped)
+	}
+}
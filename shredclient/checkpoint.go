@@ -0,0 +1,71 @@
+package shredclient
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Checkpoint 持久化已落盘 Entry 中最大的 slot，进程重启后可通过 LastSlot 读回。
+// Advance 只保留最大值，检查点本身不说明更小的 slot 是否都已写入；Sink 写入
+// 前也不查询它，重启后重复收到的 Entry 仍会再次写入。
+type Checkpoint struct {
+	path string
+
+	mu       sync.Mutex
+	lastSlot uint64
+}
+
+// LoadCheckpoint 从 path 读取已有的检查点；文件不存在时返回一个 lastSlot 为 0
+// 的全新 Checkpoint。
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	cp := &Checkpoint{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint file: %w", err)
+	}
+
+	slot, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse checkpoint file %s: %w", path, err)
+	}
+	cp.lastSlot = slot
+
+	return cp, nil
+}
+
+// LastSlot 返回已确认完整落盘的最后一个 slot。
+func (c *Checkpoint) LastSlot() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastSlot
+}
+
+// Advance 把检查点推进到 slot。写入先落到临时文件再 rename，保证任意时刻进程
+// 崩溃时磁盘上的检查点文件本身始终是完整、可解析的。
+func (c *Checkpoint) Advance(slot uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if slot <= c.lastSlot {
+		return nil
+	}
+
+	tmpPath := c.path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(strconv.FormatUint(slot, 10)), 0o644); err != nil {
+		return fmt.Errorf("write checkpoint tmp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		return fmt.Errorf("rename checkpoint file: %w", err)
+	}
+
+	c.lastSlot = slot
+	return nil
+}
@@ -0,0 +1,56 @@
+package shredclient
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpointLoadMissingFileDefaultsToZero(t *testing.T) {
+	cp, err := LoadCheckpoint(filepath.Join(t.TempDir(), "checkpoint.txt"))
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+	if got := cp.LastSlot(); got != 0 {
+		t.Fatalf("expected LastSlot 0 for a missing file, got %d", got)
+	}
+}
+
+func TestCheckpointAdvancePersistsAndReloads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.txt")
+
+	cp, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+	if err := cp.Advance(42); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if got := cp.LastSlot(); got != 42 {
+		t.Fatalf("expected LastSlot 42, got %d", got)
+	}
+
+	reloaded, err := LoadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+	if got := reloaded.LastSlot(); got != 42 {
+		t.Fatalf("expected reloaded LastSlot 42, got %d", got)
+	}
+}
+
+func TestCheckpointAdvanceIsMonotonic(t *testing.T) {
+	cp, err := LoadCheckpoint(filepath.Join(t.TempDir(), "checkpoint.txt"))
+	if err != nil {
+		t.Fatalf("LoadCheckpoint failed: %v", err)
+	}
+
+	if err := cp.Advance(10); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if err := cp.Advance(5); err != nil {
+		t.Fatalf("Advance failed: %v", err)
+	}
+	if got := cp.LastSlot(); got != 10 {
+		t.Fatalf("expected Advance to a lower slot to be a no-op, LastSlot = %d", got)
+	}
+}
@@ -0,0 +1,478 @@
+// Package shredclient 提供一个可嵌入其它项目的 Jito ShredStream 客户端：
+// 负责 gRPC 拨号、Entry 解码、过滤匹配与重连，调用方只需注册 Filter 并消费
+// Subscribe 返回的 channel。
+package shredclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/panjf2000/ants/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+
+	pb_shredstream "jito-shredstream-client/proto/shredstream"
+)
+
+const (
+	defaultPoolSize        = 10000
+	defaultReconnectWait   = 5 * time.Second
+	defaultMaxRecvMsgSize  = 1000 * 1024 * 1024 // 1000MB
+	defaultSlotGapWarn     = 10
+	defaultSlotLagInterval = 5 * time.Second
+)
+
+// EventSource 标明一个 TransactionEvent 是如何产生的。
+type EventSource int
+
+const (
+	// SourceLive 表示来自实时 gRPC 订阅。
+	SourceLive EventSource = iota
+	// SourceReplay 表示来自 Replay 对落盘 Entry 的重放。
+	SourceReplay
+	// SourceBackfill 表示来自补齐 slot 缺口的 RPC 回填。
+	SourceBackfill
+)
+
+func (s EventSource) String() string {
+	switch s {
+	case SourceLive:
+		return "live"
+	case SourceReplay:
+		return "replay"
+	case SourceBackfill:
+		return "backfill"
+	default:
+		return "unknown"
+	}
+}
+
+// TransactionEvent 是已解码并匹配到至少一个 Filter 的交易。
+type TransactionEvent struct {
+	Slot        uint64
+	Entry       *SolanaEntry
+	Transaction *solana.Transaction
+	// MatchedBy 是命中该交易的 Filter 名称列表。
+	MatchedBy []string
+	// Instructions 是按 InstructionRegistry 解码出的结构化指令，顺序与
+	// Transaction.Message.Instructions 对应；没有注册解码器的指令会被跳过。
+	Instructions []*DecodedInstruction
+	// Source 标明该事件来自实时流、replay 还是 backfill。
+	Source EventSource
+}
+
+// SubscribeOptions 配置一次 Subscribe 调用。
+type SubscribeOptions struct {
+	// Filters 是要注册的过滤器。没有任何 Filter 命中的交易不会被发送到 channel。
+	// 如果 Filters 为空，则所有交易都会被发送。
+	Filters []Filter
+
+	// ResolveLookupTables 为 true 时，v0 交易 Message.AddressTableLookups 引用的
+	// 地址会在匹配前解析出来并入账户集合，避免只通过地址查找表引用目标程序/账户
+	// 的交易被漏判。需要配合 ALTResolver 使用。
+	ResolveLookupTables bool
+	// ALTResolver 提供地址查找表解析，ResolveLookupTables 为 true 时必须设置。
+	ALTResolver *ALTResolver
+
+	// InstructionDecoders 为匹配到的交易解码结构化指令。留空则 TransactionEvent
+	// 不附带 Instructions。
+	InstructionDecoders *InstructionRegistry
+
+	// Sink 在每次收到 Entry 时落盘原始字节，供之后用 Replay 重放排查 filter/
+	// decoder 问题。留空则不落盘。
+	Sink Sink
+
+	// Backfill 在检测到 slot 跳跃时通过 RPC 回填缺失的区块。留空则跳跃只记录
+	// 告警，不做任何恢复。
+	Backfill *BackfillWorker
+}
+
+// Client 是 ShredStream 的客户端，封装了拨号、重连、解码与统计。
+type Client struct {
+	addr          string
+	dialOpts      []grpc.DialOption
+	poolSize      int
+	reconnectWait time.Duration
+	slotGapWarn   int64
+	source        EventSource
+
+	logger  *slog.Logger
+	metrics *Metrics
+
+	slotLagRPCEndpoint string
+	slotLagInterval    time.Duration
+
+	stats *stats
+}
+
+// Option 用于在 NewClient 时定制 Client 的行为。
+type Option func(*Client)
+
+// WithDialOptions 追加自定义的 gRPC 拨号选项。
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(c *Client) {
+		c.dialOpts = append(c.dialOpts, opts...)
+	}
+}
+
+// WithPoolSize 设置处理 Entry 的协程池大小，默认 10000。
+func WithPoolSize(n int) Option {
+	return func(c *Client) { c.poolSize = n }
+}
+
+// WithReconnectWait 设置断线后重试前的等待时间，默认 5 秒。
+func WithReconnectWait(d time.Duration) Option {
+	return func(c *Client) { c.reconnectWait = d }
+}
+
+// WithSlotGapWarn 设置触发 slot 跳跃告警的阈值，默认 10。
+func WithSlotGapWarn(gap int64) Option {
+	return func(c *Client) { c.slotGapWarn = gap }
+}
+
+// WithLogger 设置结构化日志输出，默认使用 slog.Default()。
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) { c.logger = logger }
+}
+
+// WithMetrics 注册一套 Prometheus 指标，Client 会在接收、解析、匹配、重连等
+// 关键路径上上报。留空则不采集指标。
+func WithMetrics(m *Metrics) Option {
+	return func(c *Client) { c.metrics = m }
+}
+
+// WithSlotLagRPC 启用 shredclient_slot_lag 指标的采集：Subscribe 会用
+// rpcEndpoint 按 interval 轮询 getSlot，和本地已处理的 slot 比较。只有同时
+// 设置了 WithMetrics 才会生效。
+func WithSlotLagRPC(rpcEndpoint string, interval time.Duration) Option {
+	return func(c *Client) {
+		c.slotLagRPCEndpoint = rpcEndpoint
+		c.slotLagInterval = interval
+	}
+}
+
+// NewClient 创建一个连接到 addr 的 ShredStream 客户端。
+func NewClient(addr string, opts ...Option) *Client {
+	c := &Client{
+		addr: addr,
+		dialOpts: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(defaultMaxRecvMsgSize)),
+			grpc.WithTimeout(30 * time.Second),
+			grpc.WithKeepaliveParams(keepalive.ClientParameters{
+				Time:                30 * time.Second,
+				Timeout:             5 * time.Second,
+				PermitWithoutStream: true,
+			}),
+		},
+		poolSize:      defaultPoolSize,
+		reconnectWait: defaultReconnectWait,
+		slotGapWarn:   defaultSlotGapWarn,
+		source:        SourceLive,
+		stats:         &stats{},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.slotLagRPCEndpoint != "" && c.slotLagInterval <= 0 {
+		c.slotLagInterval = defaultSlotLagInterval
+	}
+
+	if c.logger == nil {
+		c.logger = slog.Default()
+	}
+
+	return c
+}
+
+// Stats 返回当前的统计信息快照。
+func (c *Client) Stats() Stats { return c.stats.Snapshot() }
+
+// Subscribe 连接到 ShredStream 服务并开始接收 Entry。返回的 channel 会在 ctx
+// 取消后关闭；调用方负责持续消费该 channel，否则处理协程会被阻塞。
+func (c *Client) Subscribe(ctx context.Context, opts SubscribeOptions) (<-chan *TransactionEvent, error) {
+	events := make(chan *TransactionEvent, 1024)
+
+	if opts.Backfill != nil {
+		go opts.Backfill.Run(ctx, opts, events)
+	}
+
+	if c.metrics != nil && c.slotLagRPCEndpoint != "" {
+		go c.metrics.WatchSlotLag(ctx, c.slotLagRPCEndpoint, func() uint64 {
+			return uint64(c.stats.loadLastSlot())
+		}, c.slotLagInterval)
+	}
+
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if err := c.connectAndSubscribe(ctx, opts, events); err != nil {
+				c.stats.addReconnects(1)
+				if c.metrics != nil {
+					c.metrics.Reconnects.Inc()
+				}
+				c.logger.Warn("连接中断，准备重连",
+					slog.Duration("wait", c.reconnectWait),
+					slog.Int64("reconnect_count", c.stats.Snapshot().ReconnectCount),
+					slog.Any("err", err))
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(c.reconnectWait):
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (c *Client) connectAndSubscribe(ctx context.Context, opts SubscribeOptions, events chan<- *TransactionEvent) error {
+	conn, err := grpc.Dial(c.addr, c.dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to gRPC server: %w", err)
+	}
+	defer conn.Close()
+
+	client := pb_shredstream.NewShredstreamProxyClient(conn)
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.SubscribeEntries(streamCtx, &pb_shredstream.SubscribeEntriesRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to stream: %w", err)
+	}
+
+	pool, err := ants.NewPool(c.poolSize, ants.WithPanicHandler(func(err interface{}) {
+		c.logger.Error("协程池内 panic", slog.Any("err", err))
+		c.stats.addParseErrors(1)
+	}), ants.WithPreAlloc(true), ants.WithNonblocking(true))
+	if err != nil {
+		return fmt.Errorf("创建协程池失败: %w", err)
+	}
+	defer pool.Release()
+
+	if c.metrics != nil {
+		go c.watchPoolSaturation(streamCtx, pool)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if c.metrics != nil {
+				c.metrics.StreamErrors.Inc()
+			}
+			return fmt.Errorf("stream recv failed: %w", err)
+		}
+
+		// 复制响应以避免竞态条件
+		entryCopy := &pb_shredstream.Entry{
+			Slot:    resp.GetSlot(),
+			Entries: make([]byte, len(resp.GetEntries())),
+		}
+		copy(entryCopy.Entries, resp.GetEntries())
+
+		c.stats.addTotalEntries(1)
+		if c.metrics != nil {
+			c.metrics.EntriesReceived.Inc()
+		}
+		c.checkSlotGap(int64(entryCopy.GetSlot()), opts)
+
+		pool.Submit(func() {
+			// Sink 落盘（含 fsync）挪到协程池里做，避免磁盘 I/O 挡住
+			// stream.Recv()，造成上游 gRPC 背压。
+			if opts.Sink != nil {
+				if err := opts.Sink.WriteEntry(entryCopy.GetSlot(), entryCopy.Entries); err != nil {
+					c.logger.Warn("写入 sink 失败", slog.Uint64("slot", entryCopy.GetSlot()), slog.Any("err", err))
+				}
+			}
+			c.processEntry(ctx, entryCopy, opts, events)
+		})
+	}
+}
+
+// watchPoolSaturation 每秒采样一次协程池的使用率（running/capacity），直到
+// ctx 取消，供 Prometheus 观察处理能力是否接近饱和。
+func (c *Client) watchPoolSaturation(ctx context.Context, pool *ants.Pool) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if cap := pool.Cap(); cap > 0 {
+				c.metrics.PoolSaturation.Set(float64(pool.Running()) / float64(cap))
+			}
+		}
+	}
+}
+
+func (c *Client) checkSlotGap(currentSlot int64, opts SubscribeOptions) {
+	lastSlot := c.stats.loadLastSlot()
+	if lastSlot > 0 && currentSlot > lastSlot+c.slotGapWarn {
+		c.logger.Warn("检测到 slot 跳跃，可能丢失数据",
+			slog.Int64("last_slot", lastSlot), slog.Int64("current_slot", currentSlot))
+
+		if opts.Backfill != nil {
+			for slot := lastSlot + 1; slot < currentSlot; slot++ {
+				if !opts.Backfill.Enqueue(uint64(slot)) {
+					c.logger.Warn("backfill 队列已满，丢弃 slot", slog.Int64("slot", slot))
+				}
+			}
+		}
+	}
+	c.stats.storeLastSlot(currentSlot)
+}
+
+func (c *Client) processEntry(ctx context.Context, entry *pb_shredstream.Entry, opts SubscribeOptions, events chan<- *TransactionEvent) {
+	start := time.Now()
+	entriesData := entry.GetEntries()
+
+	if len(entriesData) < 48 {
+		c.stats.addFailedEntries(1)
+		if c.metrics != nil {
+			c.metrics.ParseFailures.Inc()
+		}
+		return
+	}
+
+	solanaEntry, err := parseJitoEntry(entriesData)
+	if err != nil {
+		c.stats.addFailedEntries(1)
+		if c.metrics != nil {
+			c.metrics.ParseFailures.Inc()
+		}
+		c.logger.Warn("解析 entry 失败", slog.Uint64("slot", entry.GetSlot()), slog.Any("err", err))
+		return
+	}
+	c.stats.addParsedEntries(1)
+	c.stats.addTotalTxs(int64(len(solanaEntry.Transactions)))
+
+	latency := time.Since(start)
+	if c.metrics != nil {
+		c.metrics.DecodeLatency.Observe(latency.Seconds())
+	}
+
+	for i := range solanaEntry.Transactions {
+		tx := &solanaEntry.Transactions[i]
+
+		matchAgainst := tx
+		if opts.ResolveLookupTables && opts.ALTResolver != nil && len(tx.Message.AddressTableLookups) > 0 {
+			matchAgainst = c.resolveForMatching(ctx, tx, opts.ALTResolver)
+		}
+
+		var matched []string
+		if len(opts.Filters) == 0 {
+			matched = nil
+		} else {
+			matched = matchFilters(opts.Filters, matchAgainst)
+			if len(matched) == 0 {
+				continue
+			}
+		}
+
+		c.stats.addMatchedTxs(1)
+		program := firstProgramID(tx)
+		if c.metrics != nil {
+			c.metrics.MatchedTxs.WithLabelValues(program).Inc()
+		}
+		c.logger.Debug("交易匹配",
+			slog.Uint64("slot", entry.GetSlot()),
+			slog.String("signature", firstSignature(tx)),
+			slog.String("program", program),
+			slog.Duration("latency", latency))
+
+		events <- &TransactionEvent{
+			Slot:         entry.GetSlot(),
+			Entry:        solanaEntry,
+			Transaction:  tx,
+			MatchedBy:    matched,
+			Instructions: decodeInstructions(tx, opts.InstructionDecoders),
+			Source:       c.source,
+		}
+	}
+}
+
+// firstSignature 返回交易的第一个签名，用于日志字段；交易没有签名时返回空
+// 字符串。
+func firstSignature(tx *solana.Transaction) string {
+	if len(tx.Signatures) == 0 {
+		return ""
+	}
+	return tx.Signatures[0].String()
+}
+
+// firstProgramID 返回交易第一条指令对应的程序地址，用于日志字段；交易没有
+// 指令或账户索引越界时返回空字符串。
+func firstProgramID(tx *solana.Transaction) string {
+	if len(tx.Message.Instructions) == 0 {
+		return ""
+	}
+	idx := tx.Message.Instructions[0].ProgramIDIndex
+	if int(idx) >= len(tx.Message.AccountKeys) {
+		return ""
+	}
+	return tx.Message.AccountKeys[idx].String()
+}
+
+// decodeInstructions 用 registry 解码交易的每条指令，没有注册解码器或解码失
+// 败的指令会被跳过，不影响其它指令的结构化输出。
+func decodeInstructions(tx *solana.Transaction, registry *InstructionRegistry) []*DecodedInstruction {
+	if registry == nil {
+		return nil
+	}
+
+	var decoded []*DecodedInstruction
+	for _, inst := range tx.Message.Instructions {
+		if int(inst.ProgramIDIndex) >= len(tx.Message.AccountKeys) {
+			continue
+		}
+		programID := tx.Message.AccountKeys[inst.ProgramIDIndex]
+
+		d, err := registry.Decode(programID, inst.Data)
+		if err != nil {
+			continue
+		}
+		decoded = append(decoded, d)
+	}
+
+	return decoded
+}
+
+// resolveForMatching 返回一份账户集合包含了已解析地址查找表条目的交易副本，
+// 仅用于过滤匹配；发给调用方的 TransactionEvent.Transaction 仍是原始交易。
+func (c *Client) resolveForMatching(ctx context.Context, tx *solana.Transaction, resolver *ALTResolver) *solana.Transaction {
+	writable, readonly, err := resolver.ResolveAccounts(ctx, &tx.Message)
+	if err != nil {
+		c.logger.Warn("解析地址查找表失败，回退为仅匹配静态账户", slog.Any("err", err))
+		return tx
+	}
+	if len(writable) == 0 && len(readonly) == 0 {
+		return tx
+	}
+
+	expanded := *tx
+	expanded.Message.AccountKeys = append(append(append([]solana.PublicKey{}, tx.Message.AccountKeys...), writable...), readonly...)
+
+	return &expanded
+}
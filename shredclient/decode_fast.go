@@ -0,0 +1,283 @@
+package shredclient
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// byteCursor 是一个不依赖反射的原始字节读取器，用于在热路径上直接解析
+// Solana 的 wire 格式，替代 bin.Decoder 对 solana.Transaction 的反射式
+// UnmarshalWithDecoder。
+type byteCursor struct {
+	data []byte
+	pos  int
+}
+
+func (c *byteCursor) remaining() int { return len(c.data) - c.pos }
+
+func (c *byteCursor) take(n int) ([]byte, error) {
+	if n < 0 || c.remaining() < n {
+		return nil, fmt.Errorf("unexpected end of data at position %d, need %d bytes, have %d", c.pos, n, c.remaining())
+	}
+	b := c.data[c.pos : c.pos+n]
+	c.pos += n
+	return b, nil
+}
+
+func (c *byteCursor) readByte() (byte, error) {
+	b, err := c.take(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (c *byteCursor) readUint64LE() (uint64, error) {
+	b, err := c.take(8)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+func (c *byteCursor) readPublicKey() (solana.PublicKey, error) {
+	b, err := c.take(32)
+	if err != nil {
+		return solana.PublicKey{}, err
+	}
+	var pk solana.PublicKey
+	copy(pk[:], b)
+	return pk, nil
+}
+
+func (c *byteCursor) readSignature() (solana.Signature, error) {
+	b, err := c.take(64)
+	if err != nil {
+		return solana.Signature{}, err
+	}
+	var sig solana.Signature
+	copy(sig[:], b)
+	return sig, nil
+}
+
+// readCompactU16 解析 Solana 的 compact-u16（shortvec）变长整数编码：每个字节
+// 低 7 位是数据，最高位为延续标志，最多 3 个字节。
+func (c *byteCursor) readCompactU16() (int, error) {
+	var result int
+	for i := 0; i < 3; i++ {
+		b, err := c.readByte()
+		if err != nil {
+			return 0, fmt.Errorf("read compact-u16 byte %d: %w", i, err)
+		}
+		result |= int(b&0x7f) << (7 * i)
+		if b&0x80 == 0 {
+			return result, nil
+		}
+	}
+	return 0, fmt.Errorf("compact-u16 exceeds 3 bytes")
+}
+
+// decodeTransactionFast 直接从原始字节解析一笔交易，对应 legacy 与 v0 两种
+// message 格式，避免 bin.Decoder 对 solana.Transaction 字段逐个反射赋值。
+func decodeTransactionFast(c *byteCursor) (*solana.Transaction, error) {
+	numSignatures, err := c.readCompactU16()
+	if err != nil {
+		return nil, fmt.Errorf("read signature count: %w", err)
+	}
+	if numSignatures > 64 {
+		return nil, fmt.Errorf("unreasonable signature count: %d", numSignatures)
+	}
+
+	signatures := make([]solana.Signature, numSignatures)
+	for i := range signatures {
+		sig, err := c.readSignature()
+		if err != nil {
+			return nil, fmt.Errorf("read signature %d: %w", i, err)
+		}
+		signatures[i] = sig
+	}
+
+	message, err := decodeMessageFast(c)
+	if err != nil {
+		return nil, fmt.Errorf("read message: %w", err)
+	}
+
+	return &solana.Transaction{
+		Signatures: signatures,
+		Message:    *message,
+	}, nil
+}
+
+func decodeMessageFast(c *byteCursor) (*solana.Message, error) {
+	// v0 消息以 0x80 置位的前缀字节开头（低 7 位是版本号），legacy 消息的第一个
+	// 字节就是 NumRequiredSignatures。
+	firstByte, err := c.readByte()
+	if err != nil {
+		return nil, fmt.Errorf("read message prefix: %w", err)
+	}
+
+	var header solana.MessageHeader
+	if firstByte&0x80 != 0 {
+		// versioned message：第一个字节只是版本前缀，header 紧随其后。
+		numRequired, err := c.readByte()
+		if err != nil {
+			return nil, fmt.Errorf("read header.NumRequiredSignatures: %w", err)
+		}
+		header.NumRequiredSignatures = numRequired
+	} else {
+		header.NumRequiredSignatures = firstByte
+	}
+
+	numReadonlySigned, err := c.readByte()
+	if err != nil {
+		return nil, fmt.Errorf("read header.NumReadonlySignedAccounts: %w", err)
+	}
+	header.NumReadonlySignedAccounts = numReadonlySigned
+
+	numReadonlyUnsigned, err := c.readByte()
+	if err != nil {
+		return nil, fmt.Errorf("read header.NumReadonlyUnsignedAccounts: %w", err)
+	}
+	header.NumReadonlyUnsignedAccounts = numReadonlyUnsigned
+
+	numAccounts, err := c.readCompactU16()
+	if err != nil {
+		return nil, fmt.Errorf("read account key count: %w", err)
+	}
+	if numAccounts > 256 {
+		return nil, fmt.Errorf("unreasonable account key count: %d", numAccounts)
+	}
+	accountKeys := make([]solana.PublicKey, numAccounts)
+	for i := range accountKeys {
+		key, err := c.readPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("read account key %d: %w", i, err)
+		}
+		accountKeys[i] = key
+	}
+
+	recentBlockhash, err := c.readPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("read recent blockhash: %w", err)
+	}
+
+	instructions, err := decodeInstructionsFast(c)
+	if err != nil {
+		return nil, fmt.Errorf("read instructions: %w", err)
+	}
+
+	message := &solana.Message{
+		Header:          header,
+		AccountKeys:     accountKeys,
+		RecentBlockhash: solana.Hash(recentBlockhash),
+		Instructions:    instructions,
+	}
+
+	if firstByte&0x80 != 0 {
+		lookups, err := decodeAddressTableLookupsFast(c)
+		if err != nil {
+			return nil, fmt.Errorf("read address table lookups: %w", err)
+		}
+		message.AddressTableLookups = lookups
+	}
+
+	return message, nil
+}
+
+func decodeInstructionsFast(c *byteCursor) ([]solana.CompiledInstruction, error) {
+	numInstructions, err := c.readCompactU16()
+	if err != nil {
+		return nil, fmt.Errorf("read instruction count: %w", err)
+	}
+	if numInstructions > 4096 {
+		return nil, fmt.Errorf("unreasonable instruction count: %d", numInstructions)
+	}
+
+	instructions := make([]solana.CompiledInstruction, numInstructions)
+	for i := range instructions {
+		programIDIndex, err := c.readByte()
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: read program id index: %w", i, err)
+		}
+
+		numAccounts, err := c.readCompactU16()
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: read account count: %w", i, err)
+		}
+		accounts := make([]uint16, numAccounts)
+		for j := range accounts {
+			idx, err := c.readByte()
+			if err != nil {
+				return nil, fmt.Errorf("instruction %d: read account index %d: %w", i, j, err)
+			}
+			accounts[j] = uint16(idx)
+		}
+
+		dataLen, err := c.readCompactU16()
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: read data length: %w", i, err)
+		}
+		data, err := c.take(dataLen)
+		if err != nil {
+			return nil, fmt.Errorf("instruction %d: read data: %w", i, err)
+		}
+
+		instructions[i] = solana.CompiledInstruction{
+			ProgramIDIndex: uint16(programIDIndex),
+			Accounts:       accounts,
+			Data:           append([]byte(nil), data...),
+		}
+	}
+
+	return instructions, nil
+}
+
+func decodeAddressTableLookupsFast(c *byteCursor) ([]solana.MessageAddressTableLookup, error) {
+	numLookups, err := c.readCompactU16()
+	if err != nil {
+		return nil, fmt.Errorf("read lookup count: %w", err)
+	}
+	if numLookups > 256 {
+		return nil, fmt.Errorf("unreasonable lookup count: %d", numLookups)
+	}
+
+	lookups := make([]solana.MessageAddressTableLookup, numLookups)
+	for i := range lookups {
+		accountKey, err := c.readPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("lookup %d: read account key: %w", i, err)
+		}
+
+		writable, err := decodeIndexListFast(c)
+		if err != nil {
+			return nil, fmt.Errorf("lookup %d: read writable indexes: %w", i, err)
+		}
+
+		readonly, err := decodeIndexListFast(c)
+		if err != nil {
+			return nil, fmt.Errorf("lookup %d: read readonly indexes: %w", i, err)
+		}
+
+		lookups[i] = solana.MessageAddressTableLookup{
+			AccountKey:      accountKey,
+			WritableIndexes: writable,
+			ReadonlyIndexes: readonly,
+		}
+	}
+
+	return lookups, nil
+}
+
+func decodeIndexListFast(c *byteCursor) ([]uint8, error) {
+	n, err := c.readCompactU16()
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := c.take(n)
+	if err != nil {
+		return nil, err
+	}
+	return append([]uint8(nil), indexes...), nil
+}
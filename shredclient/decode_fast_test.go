@@ -0,0 +1,393 @@
+package shredclient
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/gagliardetto/solana-go"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+// 下面的 fixture 构造函数在本地按 wire 格式手工拼装交易字节，用来在没有
+// 真实抓包数据的环境下（例如本沙箱）跑通基准测试；接入真实 ShredStream 后，
+// 应改为加载从线上抓取的 entries 落盘文件。
+
+func encodeCompactU16(n int) []byte {
+	var out []byte
+	for {
+		b := byte(n & 0x7f)
+		n >>= 7
+		if n != 0 {
+			out = append(out, b|0x80)
+		} else {
+			out = append(out, b)
+			break
+		}
+	}
+	return out
+}
+
+// buildFixtureTransaction 构造一笔带 1 个签名、3 个账户、1 条指令的最小合法
+// legacy 交易字节序列。
+func buildFixtureTransaction() []byte {
+	var buf []byte
+
+	// 1 个签名
+	buf = append(buf, encodeCompactU16(1)...)
+	buf = append(buf, make([]byte, 64)...)
+
+	// header: 1 个必需签名者，0 个只读签名者，1 个只读非签名者
+	buf = append(buf, 1, 0, 1)
+
+	// 3 个账户
+	buf = append(buf, encodeCompactU16(3)...)
+	for i := 0; i < 3; i++ {
+		key := make([]byte, 32)
+		key[0] = byte(i + 1)
+		buf = append(buf, key...)
+	}
+
+	// recent blockhash
+	buf = append(buf, make([]byte, 32)...)
+
+	// 1 条指令：程序索引 2，账户 [0,1]，8 字节数据
+	buf = append(buf, encodeCompactU16(1)...)
+	buf = append(buf, 2)
+	buf = append(buf, encodeCompactU16(2)...)
+	buf = append(buf, 0, 1)
+	buf = append(buf, encodeCompactU16(8)...)
+	buf = append(buf, make([]byte, 8)...)
+
+	return buf
+}
+
+// buildFixtureTransactionN 构造一笔带 1 个签名、3 个账户的最小合法 legacy
+// 交易字节序列，携带 numInstructions 条指令，每条指令 dataLen 字节数据，
+// 用来在基准测试里覆盖比单指令/8 字节固定大小更真实的指令规模。
+func buildFixtureTransactionN(numInstructions, dataLen int) []byte {
+	var buf []byte
+
+	// 1 个签名
+	buf = append(buf, encodeCompactU16(1)...)
+	buf = append(buf, make([]byte, 64)...)
+
+	// header: 1 个必需签名者，0 个只读签名者，1 个只读非签名者
+	buf = append(buf, 1, 0, 1)
+
+	// 3 个账户
+	buf = append(buf, encodeCompactU16(3)...)
+	for i := 0; i < 3; i++ {
+		key := make([]byte, 32)
+		key[0] = byte(i + 1)
+		buf = append(buf, key...)
+	}
+
+	// recent blockhash
+	buf = append(buf, make([]byte, 32)...)
+
+	buf = append(buf, encodeCompactU16(numInstructions)...)
+	for i := 0; i < numInstructions; i++ {
+		buf = append(buf, 2)
+		buf = append(buf, encodeCompactU16(2)...)
+		buf = append(buf, 0, 1)
+		buf = append(buf, encodeCompactU16(dataLen)...)
+		buf = append(buf, make([]byte, dataLen)...)
+	}
+
+	return buf
+}
+
+// buildFixtureTransactionV0 构造一笔 v0 交易：1 个签名、3 个内联账户、2 条
+// 指令，并带 1 张地址查找表（2 个可写索引 + 1 个只读索引），用来覆盖
+// AddressTableLookups 这条只有 v0 交易才会走到的解码路径。
+func buildFixtureTransactionV0() []byte {
+	var buf []byte
+
+	// 1 个签名
+	buf = append(buf, encodeCompactU16(1)...)
+	buf = append(buf, make([]byte, 64)...)
+
+	// v0 版本前缀（0x80 置位 | 版本号 0），随后才是 header。
+	buf = append(buf, 0x80)
+	buf = append(buf, 1, 0, 1)
+
+	// 3 个内联账户
+	buf = append(buf, encodeCompactU16(3)...)
+	for i := 0; i < 3; i++ {
+		key := make([]byte, 32)
+		key[0] = byte(i + 1)
+		buf = append(buf, key...)
+	}
+
+	// recent blockhash
+	buf = append(buf, make([]byte, 32)...)
+
+	// 2 条指令，数据长度不同，覆盖变长指令数据
+	buf = append(buf, encodeCompactU16(2)...)
+	buf = append(buf, 2)
+	buf = append(buf, encodeCompactU16(2)...)
+	buf = append(buf, 0, 1)
+	buf = append(buf, encodeCompactU16(8)...)
+	buf = append(buf, make([]byte, 8)...)
+
+	buf = append(buf, 2)
+	buf = append(buf, encodeCompactU16(1)...)
+	buf = append(buf, 0)
+	buf = append(buf, encodeCompactU16(32)...)
+	buf = append(buf, make([]byte, 32)...)
+
+	// 1 张地址查找表：2 个可写索引 + 1 个只读索引
+	buf = append(buf, encodeCompactU16(1)...)
+	tableKey := make([]byte, 32)
+	tableKey[0] = 0xAA
+	buf = append(buf, tableKey...)
+	buf = append(buf, encodeCompactU16(2)...)
+	buf = append(buf, 0, 1)
+	buf = append(buf, encodeCompactU16(1)...)
+	buf = append(buf, 2)
+
+	return buf
+}
+
+// buildFixtureEntry 构造一个包含 numTxns 笔交易的标准（无 Jito 头部）Entry。
+func buildFixtureEntry(numTxns int) []byte {
+	var buf []byte
+
+	numHashes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(numHashes, 1)
+	buf = append(buf, numHashes...)
+
+	buf = append(buf, make([]byte, 32)...) // hash
+
+	numTxnsBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(numTxnsBytes, uint64(numTxns))
+	buf = append(buf, numTxnsBytes...)
+
+	for i := 0; i < numTxns; i++ {
+		buf = append(buf, buildFixtureTransaction()...)
+	}
+
+	return buf
+}
+
+// buildFixtureEntryVaried 构造一个包含 numTxns 笔交易的 Entry，混合 legacy 与
+// v0 交易、不同指令数与不同指令数据大小，比 buildFixtureEntry 统一重复同一种
+// 最小交易更接近真实流量的形状。
+func buildFixtureEntryVaried(numTxns int) []byte {
+	var buf []byte
+
+	numHashes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(numHashes, 1)
+	buf = append(buf, numHashes...)
+
+	buf = append(buf, make([]byte, 32)...) // hash
+
+	numTxnsBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(numTxnsBytes, uint64(numTxns))
+	buf = append(buf, numTxnsBytes...)
+
+	shapes := []func() []byte{
+		func() []byte { return buildFixtureTransactionN(1, 8) },
+		func() []byte { return buildFixtureTransactionN(4, 32) },
+		func() []byte { return buildFixtureTransactionN(8, 128) },
+		buildFixtureTransactionV0,
+	}
+	for i := 0; i < numTxns; i++ {
+		buf = append(buf, shapes[i%len(shapes)]()...)
+	}
+
+	return buf
+}
+
+// buildFixtureJitoEntry 给标准 Entry 加上 8 字节 Jito 头部。
+func buildFixtureJitoEntry(numTxns int) []byte {
+	header := make([]byte, 8)
+	return append(header, buildFixtureEntry(numTxns)...)
+}
+
+func TestParseJitoEntryFixture(t *testing.T) {
+	data := buildFixtureJitoEntry(3)
+
+	entry, err := parseJitoEntry(data)
+	if err != nil {
+		t.Fatalf("parseJitoEntry failed: %v", err)
+	}
+	if len(entry.Transactions) != 3 {
+		t.Fatalf("expected 3 transactions, got %d", len(entry.Transactions))
+	}
+	for i, tx := range entry.Transactions {
+		if len(tx.Signatures) != 1 {
+			t.Errorf("tx %d: expected 1 signature, got %d", i, len(tx.Signatures))
+		}
+		if len(tx.Message.AccountKeys) != 3 {
+			t.Errorf("tx %d: expected 3 account keys, got %d", i, len(tx.Message.AccountKeys))
+		}
+		if len(tx.Message.Instructions) != 1 {
+			t.Errorf("tx %d: expected 1 instruction, got %d", i, len(tx.Message.Instructions))
+		}
+	}
+}
+
+// BenchmarkParseJitoEntry 近似模拟单个 Entry 携带几百笔交易的场景。
+func BenchmarkParseJitoEntry(b *testing.B) {
+	data := buildFixtureJitoEntry(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parseJitoEntry(data); err != nil {
+			b.Fatalf("parseJitoEntry failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkDecodeTransactionFast 衡量单笔交易的手写解码开销。
+func BenchmarkDecodeTransactionFast(b *testing.B) {
+	data := buildFixtureTransaction()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cursor := &byteCursor{data: data}
+		if _, err := decodeTransactionFast(cursor); err != nil {
+			b.Fatalf("decodeTransactionFast failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkDecodeTransactionReflective 衡量重写前 solana.Transaction 经
+// bin.Decoder 反射式解码单笔交易的开销，作为 BenchmarkDecodeTransactionFast
+// 的对照组，用来衡量手写解码是否达到了 >2x 吞吐的目标。
+func BenchmarkDecodeTransactionReflective(b *testing.B) {
+	data := buildFixtureTransaction()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var tx solana.Transaction
+		decoder := bin.NewBinDecoder(data)
+		if err := tx.UnmarshalWithDecoder(decoder); err != nil {
+			b.Fatalf("reflective decode failed: %v", err)
+		}
+	}
+}
+
+// parseStandardEntryReflective 是重写前逐笔交易走 bin.Decoder 反射式解码的
+// 版本，只用于基准测试里和 decodeTransactionsFast 做吞吐对比。
+func parseStandardEntryReflective(data []byte) (*SolanaEntry, error) {
+	decoder := bin.NewBinDecoder(data)
+
+	var en SolanaEntry
+	numHashes, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return nil, err
+	}
+	en.NumHashes = numHashes
+
+	hashBytes := make([]byte, 32)
+	if _, err := decoder.Read(hashBytes); err != nil {
+		return nil, err
+	}
+	copy(en.Hash[:], hashBytes)
+
+	numTxns, err := decoder.ReadUint64(bin.LE)
+	if err != nil {
+		return nil, err
+	}
+
+	en.Transactions = make([]solana.Transaction, 0, numTxns)
+	for i := uint64(0); i < numTxns; i++ {
+		var tx solana.Transaction
+		if err := tx.UnmarshalWithDecoder(decoder); err != nil {
+			return nil, err
+		}
+		en.Transactions = append(en.Transactions, tx)
+	}
+
+	return &en, nil
+}
+
+// BenchmarkParseJitoEntryReflective 是 BenchmarkParseJitoEntry 的对照组，衡量
+// 重写前逐笔交易走反射路径解析同一个几百笔交易 Entry 的开销。
+func BenchmarkParseJitoEntryReflective(b *testing.B) {
+	data := buildFixtureEntry(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parseStandardEntryReflective(data); err != nil {
+			b.Fatalf("parseStandardEntryReflective failed: %v", err)
+		}
+	}
+}
+
+func TestParseJitoEntryVariedFixture(t *testing.T) {
+	header := make([]byte, 8)
+	data := append(header, buildFixtureEntryVaried(4)...)
+
+	entry, err := parseJitoEntry(data)
+	if err != nil {
+		t.Fatalf("parseJitoEntry failed: %v", err)
+	}
+	if len(entry.Transactions) != 4 {
+		t.Fatalf("expected 4 transactions, got %d", len(entry.Transactions))
+	}
+	if len(entry.Transactions[3].Message.AddressTableLookups) != 1 {
+		t.Fatalf("expected v0 transaction to carry 1 address table lookup, got %d",
+			len(entry.Transactions[3].Message.AddressTableLookups))
+	}
+}
+
+// BenchmarkDecodeTransactionV0Fast 衡量带地址查找表的 v0 交易的手写解码开销，
+// 和 BenchmarkDecodeTransactionFast（legacy、单指令、8 字节数据）互补，避免
+// 吞吐对比只在最简单的交易形状上成立。
+func BenchmarkDecodeTransactionV0Fast(b *testing.B) {
+	data := buildFixtureTransactionV0()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cursor := &byteCursor{data: data}
+		if _, err := decodeTransactionFast(cursor); err != nil {
+			b.Fatalf("decodeTransactionFast failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkDecodeTransactionV0Reflective 是 BenchmarkDecodeTransactionV0Fast
+// 的反射式对照组。
+func BenchmarkDecodeTransactionV0Reflective(b *testing.B) {
+	data := buildFixtureTransactionV0()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var tx solana.Transaction
+		decoder := bin.NewBinDecoder(data)
+		if err := tx.UnmarshalWithDecoder(decoder); err != nil {
+			b.Fatalf("reflective decode failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkParseJitoEntryVaried 近似模拟真实流量：Entry 里混合 legacy/v0
+// 交易、不同指令数与不同指令数据大小，而不是单一最小交易重复 500 次，用来
+// 验证 >2x 吞吐的目标不只是在最佳情况下的合成数据上成立。
+func BenchmarkParseJitoEntryVaried(b *testing.B) {
+	data := buildFixtureEntryVaried(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parseStandardEntry(data); err != nil {
+			b.Fatalf("parseStandardEntry failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkParseJitoEntryVariedReflective 是 BenchmarkParseJitoEntryVaried 的
+// 反射式对照组。
+func BenchmarkParseJitoEntryVariedReflective(b *testing.B) {
+	data := buildFixtureEntryVaried(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parseStandardEntryReflective(data); err != nil {
+			b.Fatalf("parseStandardEntryReflective failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package shredclient
+
+import "github.com/gagliardetto/solana-go"
+
+// 已知主流程序的地址，供 DefaultInstructionRegistry 直接挂载内置解码器。
+var (
+	PumpFunProgramID   = solana.MustPublicKeyFromBase58("6EF8rrecthR5Dkzon8Nwu78hRvfCKubJ14M5uBEwF6P")
+	RaydiumAMMProgram  = solana.MustPublicKeyFromBase58("675kPX9MHTjS2zt1qfr1NYHuzeLXfQM9H24wFSUt1Mp8")
+	JupiterV6Program   = solana.MustPublicKeyFromBase58("JUP6LkbZbjS1jKKwapdHNy74zcZ3tLUZoi5QNyVTaV4")
+	MeteoraDLMMProgram = solana.MustPublicKeyFromBase58("LBUZKhRxPF3XUpBCjp4YzTKgLccjZhTSDM9YuVaPwxo")
+)
+
+// NewPumpFunDecoder 返回内置的 pump.fun Anchor 指令解码器，覆盖其最常用的
+// buy/sell/create 方法。完整 IDL 可通过 LoadIDL 补充。
+func NewPumpFunDecoder() *AnchorDecoder {
+	d := NewAnchorDecoder("pump.fun")
+	d.RegisterMethod(MethodDescriptor{
+		Name: "create",
+		Args: []ArgDescriptor{
+			{Name: "name", Type: ArgString},
+			{Name: "symbol", Type: ArgString},
+			{Name: "uri", Type: ArgString},
+		},
+	})
+	d.RegisterMethod(MethodDescriptor{
+		Name: "buy",
+		Args: []ArgDescriptor{
+			{Name: "amount", Type: ArgU64},
+			{Name: "maxSolCost", Type: ArgU64},
+		},
+	})
+	d.RegisterMethod(MethodDescriptor{
+		Name: "sell",
+		Args: []ArgDescriptor{
+			{Name: "amount", Type: ArgU64},
+			{Name: "minSolOutput", Type: ArgU64},
+		},
+	})
+	return d
+}
+
+// Raydium AMM v4 指令 opcode，程序不是 Anchor 程序，没有 8 字节 sighash，
+// 第一个字节就是原始 opcode。
+const (
+	raydiumOpSwapBaseIn  byte = 9
+	raydiumOpSwapBaseOut byte = 11
+)
+
+// NewRaydiumDecoder 返回内置的 Raydium AMM v4 指令解码器，覆盖最常用的
+// swapBaseIn/swapBaseOut 方法。Raydium AMM v4 不是 Anchor 程序，按原始
+// opcode（而非 Anchor sighash）解码。
+func NewRaydiumDecoder() *RaydiumDecoder {
+	d := newRaydiumDecoder("raydium")
+	d.RegisterMethod(raydiumOpSwapBaseIn, MethodDescriptor{
+		Name: "swapBaseIn",
+		Args: []ArgDescriptor{
+			{Name: "amountIn", Type: ArgU64},
+			{Name: "minimumAmountOut", Type: ArgU64},
+		},
+	})
+	d.RegisterMethod(raydiumOpSwapBaseOut, MethodDescriptor{
+		Name: "swapBaseOut",
+		Args: []ArgDescriptor{
+			{Name: "maxAmountIn", Type: ArgU64},
+			{Name: "amountOut", Type: ArgU64},
+		},
+	})
+	return d
+}
+
+// NewJupiterV6Decoder 返回内置的 Jupiter v6 聚合器指令解码器，覆盖 route。
+// sharedAccountsRoute 等带有动态 RoutePlan 的方法需要完整 IDL 才能解码，建议
+// 用 LoadIDL 补充。
+func NewJupiterV6Decoder() *AnchorDecoder {
+	d := NewAnchorDecoder("jupiter-v6")
+	d.RegisterMethod(MethodDescriptor{
+		Name: "route",
+		Args: []ArgDescriptor{
+			{Name: "inAmount", Type: ArgU64},
+			{Name: "quotedOutAmount", Type: ArgU64},
+			{Name: "slippageBps", Type: ArgU16},
+			{Name: "platformFeeBps", Type: ArgU8},
+		},
+	})
+	return d
+}
+
+// NewMeteoraDecoder 返回内置的 Meteora DLMM 指令解码器，覆盖 swap。
+func NewMeteoraDecoder() *AnchorDecoder {
+	d := NewAnchorDecoder("meteora-dlmm")
+	d.RegisterMethod(MethodDescriptor{
+		Name: "swap",
+		Args: []ArgDescriptor{
+			{Name: "amountIn", Type: ArgU64},
+			{Name: "minAmountOut", Type: ArgU64},
+		},
+	})
+	return d
+}
+
+// DefaultInstructionRegistry 返回一个已挂载 pump.fun、Raydium、Jupiter v6、
+// Meteora 内置解码器的 InstructionRegistry，调用方可以继续 Register 自己的
+// 程序，或用 LoadIDL 替换某个程序的解码器。
+func DefaultInstructionRegistry() *InstructionRegistry {
+	registry := NewInstructionRegistry()
+	registry.Register(PumpFunProgramID, NewPumpFunDecoder())
+	registry.Register(RaydiumAMMProgram, NewRaydiumDecoder())
+	registry.Register(JupiterV6Program, NewJupiterV6Decoder())
+	registry.Register(MeteoraDLMMProgram, NewMeteoraDecoder())
+	return registry
+}
@@ -0,0 +1,143 @@
+package shredclient
+
+import (
+	"fmt"
+
+	"github.com/gagliardetto/solana-go"
+
+	bin "github.com/gagliardetto/binary"
+)
+
+// SolanaEntry 是标准 Solana Entry 格式的解码结果。
+type SolanaEntry struct {
+	NumHashes    uint64               `json:"num_hashes"`
+	Hash         solana.Hash          `json:"hash"`
+	Transactions []solana.Transaction `json:"transactions"`
+}
+
+// UnmarshalWithBigEndian 按大端序解析 Entry，用于兼容部分历史数据源。
+func (en *SolanaEntry) UnmarshalWithBigEndian(decoder *bin.Decoder) (err error) {
+	// 读取 num_hashes
+	if en.NumHashes, err = decoder.ReadUint64(bin.BE); err != nil {
+		return fmt.Errorf("failed to read number of hashes: %w", err)
+	}
+
+	// 对NumHashes进行合理性检查
+	if en.NumHashes > 1000000 {
+		return fmt.Errorf("num_hashes value unreasonable: %d", en.NumHashes)
+	}
+
+	// 读取 hash (32 bytes)
+	hashBytes := make([]byte, 32)
+	if _, err = decoder.Read(hashBytes); err != nil {
+		return fmt.Errorf("failed to read hash: %w", err)
+	}
+	copy(en.Hash[:], hashBytes)
+
+	// 读取交易数量
+	var numTxns uint64
+	if numTxns, err = decoder.ReadUint64(bin.BE); err != nil {
+		return fmt.Errorf("failed to read number of transactions: %w", err)
+	}
+
+	// 检查合理性
+	if numTxns > 10000 {
+		return fmt.Errorf("transaction count unreasonable: %d", numTxns)
+	}
+
+	// 安全检查
+	estimatedBytesNeeded := numTxns * 100
+	if estimatedBytesNeeded > uint64(decoder.Remaining()) {
+		return fmt.Errorf("not enough bytes for %d transactions", numTxns)
+	}
+
+	// 读取交易
+	en.Transactions = make([]solana.Transaction, 0, numTxns)
+	for i := uint64(0); i < numTxns; i++ {
+		var tx solana.Transaction
+		if err = tx.UnmarshalWithDecoder(decoder); err != nil {
+			// 交易解析失败，但可能只是第一个交易有问题
+			// 如果至少解析出一些交易，可以继续
+			if i > 0 {
+				break
+			}
+			return fmt.Errorf("failed to decode first transaction: %w", err)
+		}
+		en.Transactions = append(en.Transactions, tx)
+	}
+
+	return nil
+}
+
+// decodeTransactionsFast 在 data 上依次手写解码 numTxns 笔交易。和原先的
+// "只有第一笔失败才报错" 行为保持一致：后续交易解析失败视为流提前结束。
+func decodeTransactionsFast(data []byte, numTxns uint64) ([]solana.Transaction, error) {
+	cursor := &byteCursor{data: data}
+
+	transactions := make([]solana.Transaction, 0, numTxns)
+	for i := uint64(0); i < numTxns; i++ {
+		txStartPos := cursor.pos
+		tx, err := decodeTransactionFast(cursor)
+		if err != nil {
+			if i == 0 {
+				return nil, fmt.Errorf("failed to decode transaction %d/%d at position %d: %w", i+1, numTxns, txStartPos, err)
+			}
+			break
+		}
+		transactions = append(transactions, *tx)
+	}
+
+	return transactions, nil
+}
+
+// parseStandardEntry 解析标准 Solana Entry（无 Jito 头部）。
+func parseStandardEntry(data []byte) (*SolanaEntry, error) {
+	cursor := &byteCursor{data: data}
+
+	numHashes, err := cursor.readUint64LE()
+	if err != nil {
+		return nil, fmt.Errorf("读取 num_hashes 失败: %w", err)
+	}
+	if numHashes > 1000000 {
+		return nil, fmt.Errorf("num_hashes 值不合理: %d", numHashes)
+	}
+
+	hash, err := cursor.readPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("读取 hash 失败: %w", err)
+	}
+
+	numTxns, err := cursor.readUint64LE()
+	if err != nil {
+		return nil, fmt.Errorf("读取交易数量失败: %w", err)
+	}
+	if numTxns > 10000 {
+		return nil, fmt.Errorf("交易数量不合理: %d", numTxns)
+	}
+
+	estimatedBytesNeeded := numTxns * 100 // 假设每个交易至少100字节
+	if estimatedBytesNeeded > uint64(cursor.remaining()) {
+		return nil, fmt.Errorf("剩余字节不足以读取 %d 个交易", numTxns)
+	}
+
+	transactions, err := decodeTransactionsFast(cursor.data[cursor.pos:], numTxns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SolanaEntry{
+		NumHashes:    numHashes,
+		Hash:         solana.Hash(hash),
+		Transactions: transactions,
+	}, nil
+}
+
+// parseJitoEntry 解析 Jito 格式 Entry（带 8 字节头部）。
+func parseJitoEntry(data []byte) (*SolanaEntry, error) {
+	if len(data) < 16 {
+		return nil, fmt.Errorf("数据太短，无法包含头部+NumHashes")
+	}
+
+	// 跳过头部信息（8字节）
+	return parseStandardEntry(data[8:])
+}
@@ -0,0 +1,154 @@
+package shredclient
+
+import (
+	"bytes"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// Filter 决定一笔交易是否与调用方关心的条件匹配。Subscribe 会对每笔解码出的
+// 交易依次运行已注册的 Filter，任意一个匹配即视为命中。
+type Filter interface {
+	// Name 返回过滤器的标识，用于在 TransactionEvent.MatchedBy 中标明命中来源。
+	Name() string
+	// Match 判断交易是否满足该过滤器的条件。
+	Match(tx *solana.Transaction) bool
+}
+
+// FilterFunc 允许调用方用一个普通函数实现 Filter，无需单独定义类型。
+type FilterFunc struct {
+	FilterName string
+	MatchFunc  func(tx *solana.Transaction) bool
+}
+
+func (f FilterFunc) Name() string { return f.FilterName }
+
+func (f FilterFunc) Match(tx *solana.Transaction) bool { return f.MatchFunc(tx) }
+
+// ProgramFilter 匹配交易中任意一条指令调用了给定的程序地址之一。
+type ProgramFilter struct {
+	name     string
+	programs map[solana.PublicKey]struct{}
+}
+
+// NewProgramFilter 创建一个按被调用程序 ID 匹配的 Filter。
+func NewProgramFilter(name string, programs ...solana.PublicKey) *ProgramFilter {
+	set := make(map[solana.PublicKey]struct{}, len(programs))
+	for _, p := range programs {
+		set[p] = struct{}{}
+	}
+	return &ProgramFilter{name: name, programs: set}
+}
+
+func (f *ProgramFilter) Name() string { return f.name }
+
+func (f *ProgramFilter) Match(tx *solana.Transaction) bool {
+	for _, inst := range tx.Message.Instructions {
+		if int(inst.ProgramIDIndex) >= len(tx.Message.AccountKeys) {
+			continue
+		}
+		programID := tx.Message.AccountKeys[inst.ProgramIDIndex]
+		if _, ok := f.programs[programID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountFilter 匹配交易账户列表中出现了给定地址之一（无论是否为签名者或可写）。
+type AccountFilter struct {
+	name     string
+	accounts map[solana.PublicKey]struct{}
+}
+
+// NewAccountFilter 创建一个按账户地址匹配的 Filter。
+func NewAccountFilter(name string, accounts ...solana.PublicKey) *AccountFilter {
+	set := make(map[solana.PublicKey]struct{}, len(accounts))
+	for _, a := range accounts {
+		set[a] = struct{}{}
+	}
+	return &AccountFilter{name: name, accounts: set}
+}
+
+func (f *AccountFilter) Name() string { return f.name }
+
+func (f *AccountFilter) Match(tx *solana.Transaction) bool {
+	for _, key := range tx.Message.AccountKeys {
+		if _, ok := f.accounts[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// SignerFilter 匹配交易的签名者列表中出现了给定地址之一。
+type SignerFilter struct {
+	name    string
+	signers map[solana.PublicKey]struct{}
+}
+
+// NewSignerFilter 创建一个按签名者地址匹配的 Filter。
+func NewSignerFilter(name string, signers ...solana.PublicKey) *SignerFilter {
+	set := make(map[solana.PublicKey]struct{}, len(signers))
+	for _, s := range signers {
+		set[s] = struct{}{}
+	}
+	return &SignerFilter{name: name, signers: set}
+}
+
+func (f *SignerFilter) Name() string { return f.name }
+
+func (f *SignerFilter) Match(tx *solana.Transaction) bool {
+	numSigners := int(tx.Message.Header.NumRequiredSignatures)
+	for i := 0; i < numSigners && i < len(tx.Message.AccountKeys); i++ {
+		if _, ok := f.signers[tx.Message.AccountKeys[i]]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// InstructionDiscriminatorFilter 匹配指令数据以给定判别符（discriminator）开头
+// 的指令，可选地限定在某个程序 ID 下。program 为 nil 表示不限制程序；
+// discriminator 为空时任意指令都会匹配。
+type InstructionDiscriminatorFilter struct {
+	name          string
+	program       *solana.PublicKey
+	discriminator []byte
+}
+
+// NewInstructionDiscriminatorFilter 创建一个按指令判别符匹配的 Filter。program
+// 传 nil 表示不限制调用的程序。
+func NewInstructionDiscriminatorFilter(name string, program *solana.PublicKey, discriminator []byte) *InstructionDiscriminatorFilter {
+	return &InstructionDiscriminatorFilter{name: name, program: program, discriminator: discriminator}
+}
+
+func (f *InstructionDiscriminatorFilter) Name() string { return f.name }
+
+func (f *InstructionDiscriminatorFilter) Match(tx *solana.Transaction) bool {
+	for _, inst := range tx.Message.Instructions {
+		if f.program != nil {
+			if int(inst.ProgramIDIndex) >= len(tx.Message.AccountKeys) {
+				continue
+			}
+			if tx.Message.AccountKeys[inst.ProgramIDIndex] != *f.program {
+				continue
+			}
+		}
+		if bytes.HasPrefix(inst.Data, f.discriminator) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFilters 返回匹配到给定交易的所有 Filter 名称。
+func matchFilters(filters []Filter, tx *solana.Transaction) []string {
+	var matched []string
+	for _, f := range filters {
+		if f.Match(tx) {
+			matched = append(matched, f.Name())
+		}
+	}
+	return matched
+}
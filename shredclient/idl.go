@@ -0,0 +1,64 @@
+package shredclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// idlFile 是 Anchor IDL JSON 里我们关心的最小子集：程序名与指令列表。
+type idlFile struct {
+	Name         string `json:"name"`
+	Instructions []struct {
+		Name string `json:"name"`
+		Args []struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"args"`
+	} `json:"instructions"`
+}
+
+// idlArgTypes 把 IDL 里常见的基础类型名映射到 ArgType。复合类型（struct、
+// vec、option 等）不在其中，LoadIDL 遇到时直接返回 error，不会跳过参数，
+// 方便调用方发现需要手工补充 MethodDescriptor 的方法。
+var idlArgTypes = map[string]ArgType{
+	"u8":        ArgU8,
+	"u16":       ArgU16,
+	"u32":       ArgU32,
+	"u64":       ArgU64,
+	"i64":       ArgI64,
+	"bool":      ArgBool,
+	"publicKey": ArgPublicKey,
+	"string":    ArgString,
+	"bytes":     ArgBytes,
+}
+
+// LoadIDL 从磁盘读取一个 Anchor IDL JSON 文件，构造出对应的 AnchorDecoder。
+// 只支持 idlArgTypes 里列出的基础参数类型；遇到不认识的类型会直接报错，而不
+// 是静默跳过参数导致解码出偏移错误的结果。
+func LoadIDL(path string) (*AnchorDecoder, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read IDL file: %w", err)
+	}
+
+	var idl idlFile
+	if err := json.Unmarshal(raw, &idl); err != nil {
+		return nil, fmt.Errorf("parse IDL file: %w", err)
+	}
+
+	decoder := NewAnchorDecoder(idl.Name)
+	for _, inst := range idl.Instructions {
+		args := make([]ArgDescriptor, 0, len(inst.Args))
+		for _, arg := range inst.Args {
+			typ, ok := idlArgTypes[arg.Type]
+			if !ok {
+				return nil, fmt.Errorf("instruction %q: unsupported arg type %q for %q", inst.Name, arg.Type, arg.Name)
+			}
+			args = append(args, ArgDescriptor{Name: arg.Name, Type: typ})
+		}
+		decoder.RegisterMethod(MethodDescriptor{Name: inst.Name, Args: args})
+	}
+
+	return decoder, nil
+}
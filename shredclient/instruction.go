@@ -0,0 +1,247 @@
+package shredclient
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/gagliardetto/solana-go"
+)
+
+// DecodedInstruction 是指令解码后的结构化结果，可以直接路由给业务 handler
+// 或序列化，替代原先 decodeInstructionData 里直接打印的字符串。
+type DecodedInstruction struct {
+	Program string
+	Method  string
+	Args    map[string]any
+}
+
+// InstructionDecoder 把某个程序的原始指令数据解码为 DecodedInstruction。
+type InstructionDecoder interface {
+	// Name 是该解码器对应的程序名称，填入 DecodedInstruction.Program。
+	Name() string
+	Decode(data []byte) (*DecodedInstruction, error)
+}
+
+// InstructionRegistry 是按程序 ID 索引的 InstructionDecoder 集合。
+type InstructionRegistry struct {
+	mu       sync.RWMutex
+	decoders map[solana.PublicKey]InstructionDecoder
+}
+
+// NewInstructionRegistry 创建一个空的指令解码器注册表。
+func NewInstructionRegistry() *InstructionRegistry {
+	return &InstructionRegistry{decoders: make(map[solana.PublicKey]InstructionDecoder)}
+}
+
+// Register 为给定程序 ID 注册一个解码器，覆盖已有的注册。
+func (r *InstructionRegistry) Register(programID solana.PublicKey, decoder InstructionDecoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decoders[programID] = decoder
+}
+
+// Decode 按程序 ID 查找解码器并解码指令数据。没有注册解码器时返回 error。
+func (r *InstructionRegistry) Decode(programID solana.PublicKey, data []byte) (*DecodedInstruction, error) {
+	r.mu.RLock()
+	decoder, ok := r.decoders[programID]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no instruction decoder registered for program %s", programID)
+	}
+
+	decoded, err := decoder.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	decoded.Program = decoder.Name()
+	return decoded, nil
+}
+
+// anchorSighash 计算 Anchor 风格的 8 字节判别符：
+// sha256("<namespace>:<name>")[:8]。全局指令的 namespace 固定为 "global"。
+func anchorSighash(namespace, name string) [8]byte {
+	sum := sha256.Sum256([]byte(namespace + ":" + name))
+	var disc [8]byte
+	copy(disc[:], sum[:8])
+	return disc
+}
+
+// ArgType 枚举 borsh 参数支持的基础类型。
+type ArgType int
+
+const (
+	ArgU8 ArgType = iota
+	ArgU16
+	ArgU32
+	ArgU64
+	ArgI64
+	ArgBool
+	ArgPublicKey
+	ArgString
+	ArgBytes
+)
+
+// ArgDescriptor 描述 Anchor 方法的一个参数：名称 + borsh 编码类型。
+type ArgDescriptor struct {
+	Name string
+	Type ArgType
+}
+
+// MethodDescriptor 描述一个 Anchor 方法：方法名 + 参数布局，用来计算判别符
+// 并解码参数。
+type MethodDescriptor struct {
+	Name string
+	Args []ArgDescriptor
+}
+
+// AnchorDecoder 是按 Anchor IDL 约定（8 字节 sighash 判别符 + borsh 参数）解码
+// 指令的通用 InstructionDecoder。
+type AnchorDecoder struct {
+	programName string
+	methods     map[[8]byte]MethodDescriptor
+}
+
+// NewAnchorDecoder 创建一个空的 AnchorDecoder，调用方通过 RegisterMethod 或
+// LoadIDL 灌入方法描述。
+func NewAnchorDecoder(programName string) *AnchorDecoder {
+	return &AnchorDecoder{programName: programName, methods: make(map[[8]byte]MethodDescriptor)}
+}
+
+// RegisterMethod 注册一个方法描述，其判别符按 "global:<method.Name>" 计算。
+func (d *AnchorDecoder) RegisterMethod(method MethodDescriptor) {
+	d.methods[anchorSighash("global", method.Name)] = method
+}
+
+func (d *AnchorDecoder) Name() string { return d.programName }
+
+func (d *AnchorDecoder) Decode(data []byte) (*DecodedInstruction, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("instruction data too short for anchor discriminator: %d bytes", len(data))
+	}
+
+	var disc [8]byte
+	copy(disc[:], data[:8])
+
+	method, ok := d.methods[disc]
+	if !ok {
+		return nil, fmt.Errorf("unknown anchor discriminator %x for program %s", disc, d.programName)
+	}
+
+	args, err := decodeBorshArgs(method.Args, data[8:])
+	if err != nil {
+		return nil, fmt.Errorf("decode args for %s.%s: %w", d.programName, method.Name, err)
+	}
+
+	return &DecodedInstruction{Method: method.Name, Args: args}, nil
+}
+
+// RaydiumDecoder 解码 Raydium AMM v4 这类非 Anchor 程序的指令：没有 8 字节
+// sighash，第一个字节是原始 opcode，其后是紧凑排布（非 borsh）的定长参数。
+type RaydiumDecoder struct {
+	programName string
+	methods     map[byte]MethodDescriptor
+}
+
+// newRaydiumDecoder 创建一个空的 RaydiumDecoder，调用方通过 RegisterMethod
+// 按 opcode 灌入方法描述。
+func newRaydiumDecoder(programName string) *RaydiumDecoder {
+	return &RaydiumDecoder{programName: programName, methods: make(map[byte]MethodDescriptor)}
+}
+
+// RegisterMethod 为给定的单字节 opcode 注册一个方法描述。
+func (d *RaydiumDecoder) RegisterMethod(opcode byte, method MethodDescriptor) {
+	d.methods[opcode] = method
+}
+
+func (d *RaydiumDecoder) Name() string { return d.programName }
+
+func (d *RaydiumDecoder) Decode(data []byte) (*DecodedInstruction, error) {
+	if len(data) < 1 {
+		return nil, fmt.Errorf("instruction data empty, no opcode for program %s", d.programName)
+	}
+
+	opcode := data[0]
+	method, ok := d.methods[opcode]
+	if !ok {
+		return nil, fmt.Errorf("unknown opcode %d for program %s", opcode, d.programName)
+	}
+
+	args, err := decodeBorshArgs(method.Args, data[1:])
+	if err != nil {
+		return nil, fmt.Errorf("decode args for %s.%s: %w", d.programName, method.Name, err)
+	}
+
+	return &DecodedInstruction{Method: method.Name, Args: args}, nil
+}
+
+func decodeBorshArgs(descriptors []ArgDescriptor, data []byte) (map[string]any, error) {
+	cursor := &byteCursor{data: data}
+	args := make(map[string]any, len(descriptors))
+
+	for _, desc := range descriptors {
+		value, err := decodeBorshArg(cursor, desc.Type)
+		if err != nil {
+			return nil, fmt.Errorf("arg %q: %w", desc.Name, err)
+		}
+		args[desc.Name] = value
+	}
+
+	return args, nil
+}
+
+func decodeBorshArg(cursor *byteCursor, typ ArgType) (any, error) {
+	switch typ {
+	case ArgU8:
+		return cursor.readByte()
+	case ArgU16:
+		b, err := cursor.take(2)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint16(b), nil
+	case ArgU32:
+		b, err := cursor.take(4)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint32(b), nil
+	case ArgU64:
+		return cursor.readUint64LE()
+	case ArgI64:
+		b, err := cursor.take(8)
+		if err != nil {
+			return nil, err
+		}
+		return int64(binary.LittleEndian.Uint64(b)), nil
+	case ArgBool:
+		b, err := cursor.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return b != 0, nil
+	case ArgPublicKey:
+		return cursor.readPublicKey()
+	case ArgString:
+		b, err := cursor.take(4)
+		if err != nil {
+			return nil, err
+		}
+		strLen := binary.LittleEndian.Uint32(b)
+		strBytes, err := cursor.take(int(strLen))
+		if err != nil {
+			return nil, err
+		}
+		return string(strBytes), nil
+	case ArgBytes:
+		b, err := cursor.take(4)
+		if err != nil {
+			return nil, err
+		}
+		byteLen := binary.LittleEndian.Uint32(b)
+		return cursor.take(int(byteLen))
+	default:
+		return nil, fmt.Errorf("unsupported arg type %d", typ)
+	}
+}
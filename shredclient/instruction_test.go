@@ -0,0 +1,179 @@
+package shredclient
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestAnchorDecoderDecodesRegisteredMethod(t *testing.T) {
+	d := NewAnchorDecoder("test-program")
+	d.RegisterMethod(MethodDescriptor{
+		Name: "buy",
+		Args: []ArgDescriptor{
+			{Name: "amount", Type: ArgU64},
+			{Name: "maxSolCost", Type: ArgU64},
+		},
+	})
+
+	disc := anchorSighash("global", "buy")
+	data := append(disc[:], make([]byte, 16)...)
+	binary.LittleEndian.PutUint64(data[8:16], 1000)
+	binary.LittleEndian.PutUint64(data[16:24], 2000)
+
+	decoded, err := d.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded.Method != "buy" {
+		t.Fatalf("expected method %q, got %q", "buy", decoded.Method)
+	}
+	if decoded.Args["amount"] != uint64(1000) || decoded.Args["maxSolCost"] != uint64(2000) {
+		t.Fatalf("unexpected args: %+v", decoded.Args)
+	}
+}
+
+func TestAnchorDecoderUnknownDiscriminator(t *testing.T) {
+	d := NewAnchorDecoder("test-program")
+	d.RegisterMethod(MethodDescriptor{Name: "buy"})
+
+	data := make([]byte, 8)
+	if _, err := d.Decode(data); err == nil {
+		t.Fatal("expected an error for an unregistered discriminator")
+	}
+}
+
+func TestAnchorDecoderDataTooShort(t *testing.T) {
+	d := NewAnchorDecoder("test-program")
+	if _, err := d.Decode([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected an error when data is shorter than the 8-byte discriminator")
+	}
+}
+
+func TestRaydiumDecoderDecodesRegisteredOpcode(t *testing.T) {
+	d := NewRaydiumDecoder()
+
+	data := make([]byte, 17)
+	data[0] = raydiumOpSwapBaseIn
+	binary.LittleEndian.PutUint64(data[1:9], 500)
+	binary.LittleEndian.PutUint64(data[9:17], 400)
+
+	decoded, err := d.Decode(data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded.Method != "swapBaseIn" {
+		t.Fatalf("expected method %q, got %q", "swapBaseIn", decoded.Method)
+	}
+	if decoded.Args["amountIn"] != uint64(500) || decoded.Args["minimumAmountOut"] != uint64(400) {
+		t.Fatalf("unexpected args: %+v", decoded.Args)
+	}
+}
+
+func TestRaydiumDecoderUnknownOpcode(t *testing.T) {
+	d := NewRaydiumDecoder()
+	if _, err := d.Decode([]byte{99}); err == nil {
+		t.Fatal("expected an error for an unregistered opcode")
+	}
+}
+
+func TestRaydiumDecoderEmptyData(t *testing.T) {
+	d := NewRaydiumDecoder()
+	if _, err := d.Decode(nil); err == nil {
+		t.Fatal("expected an error when instruction data has no opcode byte")
+	}
+}
+
+func TestDecodeBorshArgsAllTypes(t *testing.T) {
+	descriptors := []ArgDescriptor{
+		{Name: "a", Type: ArgU8},
+		{Name: "b", Type: ArgU16},
+		{Name: "c", Type: ArgU32},
+		{Name: "d", Type: ArgU64},
+		{Name: "e", Type: ArgI64},
+		{Name: "f", Type: ArgBool},
+		{Name: "g", Type: ArgPublicKey},
+		{Name: "h", Type: ArgString},
+		{Name: "i", Type: ArgBytes},
+	}
+
+	var data []byte
+	data = append(data, 7)                                 // a: u8
+	data = append(data, 0, 0)                              // b: u16
+	binary.LittleEndian.PutUint16(data[1:3], 1000)         // b
+	data = append(data, 0, 0, 0, 0)                        // c: u32
+	binary.LittleEndian.PutUint32(data[3:7], 100000)       // c
+	data = append(data, make([]byte, 8)...)                // d: u64
+	binary.LittleEndian.PutUint64(data[7:15], 1<<40)       // d
+	data = append(data, make([]byte, 8)...)                // e: i64
+	binary.LittleEndian.PutUint64(data[15:23], ^uint64(0)) // e: -1
+	data = append(data, 1)                                 // f: bool true
+	data = append(data, make([]byte, 32)...)               // g: pubkey
+	data[24] = 0xAB                                        // mark pubkey bytes
+	data = append(data, 0, 0, 0, 0)                        // h: string len prefix
+	binary.LittleEndian.PutUint32(data[len(data)-4:], 5)   // h: len 5
+	data = append(data, []byte("hello")...)                // h: string bytes
+	data = append(data, 0, 0, 0, 0)                        // i: bytes len prefix
+	binary.LittleEndian.PutUint32(data[len(data)-4:], 3)   // i: len 3
+	data = append(data, []byte{9, 8, 7}...)                // i: bytes
+
+	args, err := decodeBorshArgs(descriptors, data)
+	if err != nil {
+		t.Fatalf("decodeBorshArgs failed: %v", err)
+	}
+
+	if args["a"] != byte(7) {
+		t.Errorf("a: got %v", args["a"])
+	}
+	if args["b"] != uint16(1000) {
+		t.Errorf("b: got %v", args["b"])
+	}
+	if args["c"] != uint32(100000) {
+		t.Errorf("c: got %v", args["c"])
+	}
+	if args["d"] != uint64(1<<40) {
+		t.Errorf("d: got %v", args["d"])
+	}
+	if args["e"] != int64(-1) {
+		t.Errorf("e: got %v", args["e"])
+	}
+	if args["f"] != true {
+		t.Errorf("f: got %v", args["f"])
+	}
+	if args["h"] != "hello" {
+		t.Errorf("h: got %v", args["h"])
+	}
+	if b, ok := args["i"].([]byte); !ok || string(b) != "\x09\x08\x07" {
+		t.Errorf("i: got %v", args["i"])
+	}
+}
+
+func TestDecodeBorshArgsTruncatedData(t *testing.T) {
+	descriptors := []ArgDescriptor{{Name: "amount", Type: ArgU64}}
+	if _, err := decodeBorshArgs(descriptors, []byte{1, 2, 3}); err == nil {
+		t.Fatal("expected an error when data is shorter than the declared arg layout")
+	}
+}
+
+func TestInstructionRegistryDecodeRoutesByProgramID(t *testing.T) {
+	registry := DefaultInstructionRegistry()
+
+	disc := anchorSighash("global", "buy")
+	data := append(disc[:], make([]byte, 16)...)
+	binary.LittleEndian.PutUint64(data[8:16], 1)
+	binary.LittleEndian.PutUint64(data[16:24], 2)
+
+	decoded, err := registry.Decode(PumpFunProgramID, data)
+	if err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if decoded.Program != "pump.fun" || decoded.Method != "buy" {
+		t.Fatalf("unexpected decode result: %+v", decoded)
+	}
+}
+
+func TestInstructionRegistryDecodeUnregisteredProgram(t *testing.T) {
+	registry := NewInstructionRegistry()
+	if _, err := registry.Decode(PumpFunProgramID, []byte{1, 2, 3}); err == nil {
+		t.Fatal("expected an error for a program with no registered decoder")
+	}
+}
@@ -0,0 +1,119 @@
+package shredclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics 持有运行时观测所需的全部 Prometheus 指标，替代原先的
+// printStats 打印 goroutine。
+type Metrics struct {
+	registry *prometheus.Registry
+
+	EntriesReceived prometheus.Counter
+	ParseFailures   prometheus.Counter
+	MatchedTxs      *prometheus.CounterVec
+	DecodeLatency   prometheus.Histogram
+	SlotLag         prometheus.Gauge
+	Reconnects      prometheus.Counter
+	StreamErrors    prometheus.Counter
+	PoolSaturation  prometheus.Gauge
+}
+
+// NewMetrics 创建一套独立的 Prometheus 指标（使用自己的 Registry，不污染
+// prometheus.DefaultRegisterer），可以重复创建用于多个 Client 实例。
+func NewMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+	factory := promauto.With(registry)
+
+	return &Metrics{
+		registry: registry,
+		EntriesReceived: factory.NewCounter(prometheus.CounterOpts{
+			Name: "shredclient_entries_received_total",
+			Help: "收到的 ShredStream Entry 总数。",
+		}),
+		ParseFailures: factory.NewCounter(prometheus.CounterOpts{
+			Name: "shredclient_parse_failures_total",
+			Help: "Entry 解析失败的总数。",
+		}),
+		MatchedTxs: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "shredclient_matched_transactions_total",
+			Help: "按交易首个指令所属程序分组的匹配交易总数。",
+		}, []string{"program"}),
+		DecodeLatency: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "shredclient_decode_latency_seconds",
+			Help:    "单个 Entry 的解析耗时（不含排队与 Sink 落盘）。",
+			Buckets: prometheus.DefBuckets,
+		}),
+		SlotLag: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "shredclient_slot_lag",
+			Help: "当前处理到的 slot 与 RPC getSlot 的差值。",
+		}),
+		Reconnects: factory.NewCounter(prometheus.CounterOpts{
+			Name: "shredclient_reconnects_total",
+			Help: "gRPC 重连次数。",
+		}),
+		StreamErrors: factory.NewCounter(prometheus.CounterOpts{
+			Name: "shredclient_stream_errors_total",
+			Help: "gRPC 流读取错误次数。",
+		}),
+		PoolSaturation: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "shredclient_pool_saturation_ratio",
+			Help: "ants 协程池使用率（running/capacity）。",
+		}),
+	}
+}
+
+// Serve 在 addr 上同时提供 /metrics（Prometheus 抓取端点）和
+// /debug/pprof/*（运行时剖析端点），阻塞直到 ctx 取消或监听出错。
+func (m *Metrics) Serve(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	server := &http.Server{Addr: addr, Handler: mux}
+
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("metrics server: %w", err)
+	}
+	return nil
+}
+
+// WatchSlotLag 每隔 interval 调用一次 RPC getSlot，和 currentSlot() 返回的本
+// 地已处理 slot 比较，更新 SlotLag gauge，直到 ctx 取消。
+func (m *Metrics) WatchSlotLag(ctx context.Context, rpcEndpoint string, currentSlot func() uint64, interval time.Duration) {
+	client := rpc.New(rpcEndpoint)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			remoteSlot, err := client.GetSlot(ctx, rpc.CommitmentFinalized)
+			if err != nil {
+				continue
+			}
+			lag := int64(remoteSlot) - int64(currentSlot())
+			m.SlotLag.Set(float64(lag))
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package shredclient
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsServeExposesRegisteredCounters(t *testing.T) {
+	m := NewMetrics()
+	m.EntriesReceived.Add(3)
+	m.MatchedTxs.WithLabelValues("pump.fun").Inc()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen failed: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- m.Serve(ctx, addr) }()
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = http.Get("http://" + addr + "/metrics")
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("GET /metrics failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response body failed: %v", err)
+	}
+
+	if !strings.Contains(string(body), "shredclient_entries_received_total 3") {
+		t.Fatalf("expected /metrics to report entries_received=3, got:\n%s", body)
+	}
+	if !strings.Contains(string(body), `shredclient_matched_transactions_total{program="pump.fun"} 1`) {
+		t.Fatalf("expected /metrics to report matched_transactions labeled by program, got:\n%s", body)
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("Serve returned error after shutdown: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Serve did not shut down after context cancellation")
+	}
+}
@@ -0,0 +1,112 @@
+package shredclient
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pb_shredstream "jito-shredstream-client/proto/shredstream"
+)
+
+// ReplayOptions 配置一次 Replay 调用，字段与 SubscribeOptions 对应的部分含义
+// 相同，只是没有网络连接相关的选项。
+type ReplayOptions struct {
+	Filters             []Filter
+	ResolveLookupTables bool
+	ALTResolver         *ALTResolver
+	InstructionDecoders *InstructionRegistry
+}
+
+// Replay 从 JSONLSink 写入的目录里按 slot 顺序读回原始 Entry 字节，重新走一遍
+// 和实时订阅完全相同的 filter/decoder 流水线，不需要任何 gRPC 连接。这让调试
+// filter、instruction decoder 的正确性不必依赖一个活跃的 ShredStream 连接。
+func Replay(ctx context.Context, dir string, opts ReplayOptions) (<-chan *TransactionEvent, error) {
+	files, err := listEntryPartitions(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *TransactionEvent, 1024)
+	replayClient := &Client{source: SourceReplay, stats: &stats{}, logger: slog.Default()}
+	subOpts := SubscribeOptions{
+		Filters:             opts.Filters,
+		ResolveLookupTables: opts.ResolveLookupTables,
+		ALTResolver:         opts.ALTResolver,
+		InstructionDecoders: opts.InstructionDecoders,
+	}
+
+	go func() {
+		defer close(events)
+		for _, path := range files {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if err := replayFile(ctx, replayClient, path, subOpts, events); err != nil {
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// listEntryPartitions 列出 dir 下所有 JSONLSink 写入的分区文件，按 slot 区间
+// 升序排列——分区文件名里的起始 slot 是定长零填充，字典序即 slot 序。
+func listEntryPartitions(dir string) ([]string, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read replay dir: %w", err)
+	}
+
+	var files []string
+	for _, e := range dirEntries {
+		if e.IsDir() {
+			continue
+		}
+		name := e.Name()
+		if strings.HasPrefix(name, "entries-") && strings.HasSuffix(name, ".jsonl") {
+			files = append(files, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func replayFile(ctx context.Context, c *Client, path string, opts SubscribeOptions, events chan<- *TransactionEvent) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		var record jsonlRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("decode record in %s: %w", path, err)
+		}
+
+		entry := &pb_shredstream.Entry{Slot: record.Slot, Entries: record.Data}
+		c.processEntry(ctx, entry, opts, events)
+	}
+
+	return scanner.Err()
+}
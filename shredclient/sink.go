@@ -0,0 +1,135 @@
+package shredclient
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Sink 持久化收到的原始 Entry 字节，供 Replay 之后重放。调用方可以实现自己的
+// Sink 接入 Kafka、NATS、ClickHouse 等系统；内置了 JSONLSink 和 ParquetSink。
+type Sink interface {
+	// WriteEntry 落盘一个 Entry 的 slot 与解析前的原始字节（parseJitoEntry 的
+	// 输入）。
+	WriteEntry(slot uint64, raw []byte) error
+	Close() error
+}
+
+// jsonlRecord 是 JSONLSink 落盘的一行记录；Data 会被 encoding/json 自动编码
+// 为 base64 字符串。
+type jsonlRecord struct {
+	Slot uint64 `json:"slot"`
+	Data []byte `json:"data"`
+}
+
+// JSONLSink 把 Entry 按 slot 区间分区，追加写入 JSONL 文件，并维护一份检查点
+// 文件记录最后完整写入的 slot。
+type JSONLSink struct {
+	dir          string
+	slotsPerFile uint64
+	checkpoint   *Checkpoint
+
+	mu             sync.Mutex
+	file           *os.File
+	writer         *bufio.Writer
+	partitionStart uint64
+}
+
+// NewJSONLSink 创建一个把分区文件写到 dir 下的 JSONLSink，每个分区文件覆盖
+// slotsPerFile 个 slot。
+func NewJSONLSink(dir string, slotsPerFile uint64) (*JSONLSink, error) {
+	if slotsPerFile == 0 {
+		return nil, fmt.Errorf("slotsPerFile must be > 0")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create sink dir: %w", err)
+	}
+
+	checkpoint, err := LoadCheckpoint(filepath.Join(dir, "checkpoint.txt"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONLSink{dir: dir, slotsPerFile: slotsPerFile, checkpoint: checkpoint}, nil
+}
+
+// LastCheckpointedSlot 返回目录下已确认完整落盘的最后一个 slot。
+func (s *JSONLSink) LastCheckpointedSlot() uint64 { return s.checkpoint.LastSlot() }
+
+func (s *JSONLSink) WriteEntry(slot uint64, raw []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFile(slot); err != nil {
+		return err
+	}
+
+	line, err := json.Marshal(jsonlRecord{Slot: slot, Data: raw})
+	if err != nil {
+		return fmt.Errorf("marshal jsonl record: %w", err)
+	}
+	if _, err := s.writer.Write(line); err != nil {
+		return fmt.Errorf("write jsonl record: %w", err)
+	}
+	if err := s.writer.WriteByte('\n'); err != nil {
+		return fmt.Errorf("write jsonl record: %w", err)
+	}
+	if err := s.writer.Flush(); err != nil {
+		return fmt.Errorf("flush jsonl sink: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("sync jsonl sink: %w", err)
+	}
+
+	return s.checkpoint.Advance(slot)
+}
+
+func (s *JSONLSink) ensureFile(slot uint64) error {
+	partitionStart := (slot / s.slotsPerFile) * s.slotsPerFile
+	if s.file != nil && partitionStart == s.partitionStart {
+		return nil
+	}
+
+	if s.file != nil {
+		if err := s.writer.Flush(); err != nil {
+			return fmt.Errorf("flush previous partition: %w", err)
+		}
+		if err := s.file.Close(); err != nil {
+			return fmt.Errorf("close previous partition: %w", err)
+		}
+	}
+
+	path := filepath.Join(s.dir, partitionFileName("entries", partitionStart, s.slotsPerFile, "jsonl"))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open partition file %s: %w", path, err)
+	}
+
+	s.file = f
+	s.writer = bufio.NewWriter(f)
+	s.partitionStart = partitionStart
+
+	return nil
+}
+
+func (s *JSONLSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	if err := s.writer.Flush(); err != nil {
+		return fmt.Errorf("flush jsonl sink: %w", err)
+	}
+	return s.file.Close()
+}
+
+// partitionFileName 生成定长零填充的分区文件名，保证按文件名字典序排列就是
+// 按 slot 区间排列，Replay 扫描目录时无需额外解析文件名里的数字再排序。
+func partitionFileName(prefix string, partitionStart, slotsPerFile uint64, ext string) string {
+	return fmt.Sprintf("%s-%020d-%020d.%s", prefix, partitionStart, partitionStart+slotsPerFile-1, ext)
+}
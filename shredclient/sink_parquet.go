@@ -0,0 +1,164 @@
+package shredclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetRow 是 ParquetSink 落盘的行结构，和 jsonlRecord 语义一致，只是列式
+// 存储方便之后做分析查询。
+type parquetRow struct {
+	Slot uint64 `parquet:"slot"`
+	Data []byte `parquet:"data"`
+}
+
+// ParquetSink 是 JSONLSink 的列式存储版本，适合离线分析；落盘语义（分区、
+// 检查点）与 JSONLSink 保持一致。Replay 只读取 JSONL 分区文件，不读取
+// ParquetSink 写出的 .parquet 分区。
+type ParquetSink struct {
+	dir          string
+	slotsPerFile uint64
+	checkpoint   *Checkpoint
+
+	mu             sync.Mutex
+	file           *os.File
+	writer         *parquet.GenericWriter[parquetRow]
+	partitionStart uint64
+}
+
+// NewParquetSink 创建一个把分区文件写到 dir 下的 ParquetSink。
+func NewParquetSink(dir string, slotsPerFile uint64) (*ParquetSink, error) {
+	if slotsPerFile == 0 {
+		return nil, fmt.Errorf("slotsPerFile must be > 0")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create sink dir: %w", err)
+	}
+
+	checkpoint, err := LoadCheckpoint(filepath.Join(dir, "checkpoint.txt"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ParquetSink{dir: dir, slotsPerFile: slotsPerFile, checkpoint: checkpoint}, nil
+}
+
+func (s *ParquetSink) WriteEntry(slot uint64, raw []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ensureFile(slot); err != nil {
+		return err
+	}
+
+	if _, err := s.writer.Write([]parquetRow{{Slot: slot, Data: raw}}); err != nil {
+		return fmt.Errorf("write parquet row: %w", err)
+	}
+	if err := s.writer.Flush(); err != nil {
+		return fmt.Errorf("flush parquet sink: %w", err)
+	}
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("sync parquet sink: %w", err)
+	}
+
+	return s.checkpoint.Advance(slot)
+}
+
+func (s *ParquetSink) ensureFile(slot uint64) error {
+	partitionStart := (slot / s.slotsPerFile) * s.slotsPerFile
+	if s.file != nil && partitionStart == s.partitionStart {
+		return nil
+	}
+
+	if s.file != nil {
+		if err := s.closeCurrentLocked(); err != nil {
+			return fmt.Errorf("close previous partition: %w", err)
+		}
+	}
+
+	path := filepath.Join(s.dir, partitionFileName("entries", partitionStart, s.slotsPerFile, "parquet"))
+
+	// parquet 的 footer 只在文件关闭时一次性写出，不支持真正的追加；重启后
+	// 重新打开同一分区时，先把已经落盘（Checkpoint 已记为持久化）的行读出
+	// 来，再连同新行一起重写，避免 O_TRUNC 把它们覆盖掉。
+	existing, err := readExistingParquetRows(path)
+	if err != nil {
+		return fmt.Errorf("read existing partition %s: %w", path, err)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("open partition file %s: %w", path, err)
+	}
+
+	writer := parquet.NewGenericWriter[parquetRow](f)
+	if len(existing) > 0 {
+		if _, err := writer.Write(existing); err != nil {
+			f.Close()
+			return fmt.Errorf("rewrite existing rows for %s: %w", path, err)
+		}
+	}
+
+	s.file = f
+	s.writer = writer
+	s.partitionStart = partitionStart
+
+	return nil
+}
+
+// readExistingParquetRows 读出 path 下已落盘的行，供 ensureFile 在重新打开
+// 同一分区文件时保留。文件不存在或为空时返回空切片、nil error。
+func readExistingParquetRows(path string) ([]parquetRow, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 {
+		return nil, nil
+	}
+
+	reader := parquet.NewGenericReader[parquetRow](f)
+	defer reader.Close()
+
+	rows := make([]parquetRow, reader.NumRows())
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	n, err := reader.Read(rows)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("read parquet rows: %w", err)
+	}
+	return rows[:n], nil
+}
+
+func (s *ParquetSink) closeCurrentLocked() error {
+	if err := s.writer.Close(); err != nil {
+		return fmt.Errorf("close parquet writer: %w", err)
+	}
+	return s.file.Close()
+}
+
+func (s *ParquetSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	return s.closeCurrentLocked()
+}
@@ -0,0 +1,100 @@
+package shredclient
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParquetSinkWriteEntryChecksPoints(t *testing.T) {
+	dir := t.TempDir()
+
+	sink, err := NewParquetSink(dir, 1000)
+	if err != nil {
+		t.Fatalf("NewParquetSink failed: %v", err)
+	}
+
+	if err := sink.WriteEntry(1, []byte("entry-1")); err != nil {
+		t.Fatalf("WriteEntry failed: %v", err)
+	}
+	if err := sink.WriteEntry(2, []byte("entry-2")); err != nil {
+		t.Fatalf("WriteEntry failed: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if got := sink.checkpoint.LastSlot(); got != 2 {
+		t.Fatalf("expected checkpoint at slot 2, got %d", got)
+	}
+
+	rows := readPartitionRows(t, dir)
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows in partition file, got %d", len(rows))
+	}
+	if string(rows[0].Data) != "entry-1" || string(rows[1].Data) != "entry-2" {
+		t.Fatalf("unexpected row contents: %+v", rows)
+	}
+}
+
+// TestParquetSinkReopenPreservesExistingRows 模拟进程重启后重新打开同一个
+// 分区文件：parquet 的 footer 只在 Close 时写一次，不支持真正的追加，
+// ensureFile 必须先把已落盘的行读回来再连同新行一起重写，否则 O_TRUNC 会把
+// 它们覆盖掉。
+func TestParquetSinkReopenPreservesExistingRows(t *testing.T) {
+	dir := t.TempDir()
+
+	sink, err := NewParquetSink(dir, 1000)
+	if err != nil {
+		t.Fatalf("NewParquetSink failed: %v", err)
+	}
+	if err := sink.WriteEntry(1, []byte("entry-1")); err != nil {
+		t.Fatalf("WriteEntry failed: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// 模拟进程重启：重新打开同一个 dir，落在同一个分区里。
+	reopened, err := NewParquetSink(dir, 1000)
+	if err != nil {
+		t.Fatalf("NewParquetSink (reopen) failed: %v", err)
+	}
+	if err := reopened.WriteEntry(2, []byte("entry-2")); err != nil {
+		t.Fatalf("WriteEntry failed: %v", err)
+	}
+	if err := reopened.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	rows := readPartitionRows(t, dir)
+	if len(rows) != 2 {
+		t.Fatalf("expected the restart to preserve the first row alongside the new one, got %d rows", len(rows))
+	}
+	if string(rows[0].Data) != "entry-1" || string(rows[1].Data) != "entry-2" {
+		t.Fatalf("unexpected row contents after reopen: %+v", rows)
+	}
+}
+
+func readPartitionRows(t *testing.T, dir string) []parquetRow {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	var partitionPath string
+	for _, e := range entries {
+		if e.Name() != "checkpoint.txt" {
+			partitionPath = dir + "/" + e.Name()
+		}
+	}
+	if partitionPath == "" {
+		t.Fatal("expected a partition file to have been written")
+	}
+
+	rows, err := readExistingParquetRows(partitionPath)
+	if err != nil {
+		t.Fatalf("readExistingParquetRows failed: %v", err)
+	}
+	return rows
+}
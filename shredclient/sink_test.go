@@ -0,0 +1,99 @@
+package shredclient
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSONLSinkWriteEntryAppendsAndCheckpoints(t *testing.T) {
+	dir := t.TempDir()
+
+	sink, err := NewJSONLSink(dir, 1000)
+	if err != nil {
+		t.Fatalf("NewJSONLSink failed: %v", err)
+	}
+
+	if err := sink.WriteEntry(1, []byte("entry-1")); err != nil {
+		t.Fatalf("WriteEntry failed: %v", err)
+	}
+	if err := sink.WriteEntry(2, []byte("entry-2")); err != nil {
+		t.Fatalf("WriteEntry failed: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if got := sink.LastCheckpointedSlot(); got != 2 {
+		t.Fatalf("expected checkpoint at slot 2, got %d", got)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	var partitionPath string
+	for _, e := range entries {
+		if e.Name() != "checkpoint.txt" {
+			partitionPath = filepath.Join(dir, e.Name())
+		}
+	}
+	if partitionPath == "" {
+		t.Fatal("expected a partition file to have been written")
+	}
+
+	f, err := os.Open(partitionPath)
+	if err != nil {
+		t.Fatalf("open partition file failed: %v", err)
+	}
+	defer f.Close()
+
+	var rows []jsonlRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var row jsonlRecord
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatalf("unmarshal row failed: %v", err)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows in partition file, got %d", len(rows))
+	}
+	if string(rows[0].Data) != "entry-1" || string(rows[1].Data) != "entry-2" {
+		t.Fatalf("unexpected row contents: %+v", rows)
+	}
+}
+
+func TestJSONLSinkRotatesPartitionOnSlotBoundary(t *testing.T) {
+	dir := t.TempDir()
+
+	sink, err := NewJSONLSink(dir, 10)
+	if err != nil {
+		t.Fatalf("NewJSONLSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	if err := sink.WriteEntry(5, []byte("a")); err != nil {
+		t.Fatalf("WriteEntry failed: %v", err)
+	}
+	if err := sink.WriteEntry(15, []byte("b")); err != nil {
+		t.Fatalf("WriteEntry failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	partitionCount := 0
+	for _, e := range entries {
+		if e.Name() != "checkpoint.txt" {
+			partitionCount++
+		}
+	}
+	if partitionCount != 2 {
+		t.Fatalf("expected 2 partition files after crossing a slot boundary, got %d", partitionCount)
+	}
+}
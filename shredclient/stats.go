@@ -0,0 +1,52 @@
+package shredclient
+
+import "sync/atomic"
+
+// Stats 是某一时刻的统计信息快照。
+type Stats struct {
+	TotalEntries   int64
+	ParsedEntries  int64
+	FailedEntries  int64
+	TotalTxs       int64
+	MatchedTxs     int64
+	ParseErrors    int64
+	ReconnectCount int64
+	LastSlot       int64
+}
+
+// stats 持有并发安全的运行时计数器。
+type stats struct {
+	totalEntries   int64
+	parsedEntries  int64
+	failedEntries  int64
+	totalTxs       int64
+	matchedTxs     int64
+	parseErrors    int64
+	reconnectCount int64
+	lastSlot       int64
+}
+
+func (s *stats) addTotalEntries(n int64)  { atomic.AddInt64(&s.totalEntries, n) }
+func (s *stats) addParsedEntries(n int64) { atomic.AddInt64(&s.parsedEntries, n) }
+func (s *stats) addFailedEntries(n int64) { atomic.AddInt64(&s.failedEntries, n) }
+func (s *stats) addTotalTxs(n int64)      { atomic.AddInt64(&s.totalTxs, n) }
+func (s *stats) addMatchedTxs(n int64)    { atomic.AddInt64(&s.matchedTxs, n) }
+func (s *stats) addParseErrors(n int64)   { atomic.AddInt64(&s.parseErrors, n) }
+func (s *stats) addReconnects(n int64)    { atomic.AddInt64(&s.reconnectCount, n) }
+
+func (s *stats) storeLastSlot(slot int64) { atomic.StoreInt64(&s.lastSlot, slot) }
+func (s *stats) loadLastSlot() int64      { return atomic.LoadInt64(&s.lastSlot) }
+
+// Snapshot 返回当前统计信息的一份拷贝。
+func (s *stats) Snapshot() Stats {
+	return Stats{
+		TotalEntries:   atomic.LoadInt64(&s.totalEntries),
+		ParsedEntries:  atomic.LoadInt64(&s.parsedEntries),
+		FailedEntries:  atomic.LoadInt64(&s.failedEntries),
+		TotalTxs:       atomic.LoadInt64(&s.totalTxs),
+		MatchedTxs:     atomic.LoadInt64(&s.matchedTxs),
+		ParseErrors:    atomic.LoadInt64(&s.parseErrors),
+		ReconnectCount: atomic.LoadInt64(&s.reconnectCount),
+		LastSlot:       atomic.LoadInt64(&s.lastSlot),
+	}
+}